@@ -0,0 +1,269 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Client renders a typed HTTP client with one method per route recorded on
+// the spec: request/response bodies are typed via the structs from
+// Structs, path/query/header parameters are bound from Go arguments, and
+// the request body is encoded according to the route's MIME type via the
+// generated encodeBody helper.
+func (g *Generator) Client() (string, error) {
+	ops := g.operations()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", g.Package)
+	b.WriteString(clientPreamble)
+
+	for _, op := range ops {
+		writeClientMethod(&b, op)
+	}
+
+	return formatSource(b.String())
+}
+
+const clientPreamble = `import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Client is a typed HTTP client generated from an OpenAPI spec.
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a Client targeting baseURL, using http.DefaultClient.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL, HTTPClient: http.DefaultClient}
+}
+
+// encodeBody encodes body (one of the generated request structs) for the
+// given content type, matching the MIME type recorded on the route's
+// request body, and returns the exact Content-Type header value to send -
+// for multipart this carries the writer's boundary parameter, which
+// contentType alone does not.
+func encodeBody(contentType string, body any) ([]byte, string, error) {
+	switch contentType {
+	case "application/xml":
+		data, err := xml.Marshal(body)
+		return data, contentType, err
+
+	case "application/x-www-form-urlencoded":
+		values, err := formValues(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("encodeBody: %s: %w", contentType, err)
+		}
+		return []byte(values.Encode()), contentType, nil
+
+	case "multipart/form-data":
+		values, err := formValues(body)
+		if err != nil {
+			return nil, "", fmt.Errorf("encodeBody: %s: %w", contentType, err)
+		}
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		for k, vs := range values {
+			for _, v := range vs {
+				if err := w.WriteField(k, v); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+		if err := w.Close(); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), w.FormDataContentType(), nil
+
+	default:
+		data, err := json.Marshal(body)
+		return data, contentType, err
+	}
+}
+
+// formValues flattens a request struct's exported fields into url.Values
+// for the form-encoded and multipart encoders above, keyed by each
+// field's "json" tag name (falling back to the Go field name) and
+// skipping omitempty fields left at their zero value.
+func formValues(body any) (url.Values, error) {
+	v := reflect.ValueOf(body)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return url.Values{}, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form encoding requires a struct, got %T", body)
+	}
+
+	values := url.Values{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		omitempty := false
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				if opt == "omitempty" {
+					omitempty = true
+				}
+			}
+		}
+
+		fv := v.Field(i)
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				fv = reflect.Value{}
+				break
+			}
+			fv = fv.Elem()
+		}
+		if !fv.IsValid() || (omitempty && fv.IsZero()) {
+			continue
+		}
+
+		values.Set(name, fmt.Sprintf("%v", fv.Interface()))
+	}
+
+	return values, nil
+}
+
+`
+
+// writeClientMethod appends the Client method for a single operation.
+func writeClientMethod(b *strings.Builder, op operation) {
+	reqType := "any"
+	if op.Req != nil {
+		reqType = goTypeForRef(op.Req.Ref, op.Req.Array)
+	}
+	respType := "any"
+	if op.Resp != nil {
+		respType = goTypeForRef(op.Resp.Ref, op.Resp.Array)
+	}
+
+	if op.Desc != "" {
+		fmt.Fprintf(b, "// %s %s\n", op.Name, op.Desc)
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range op.Params {
+		args = append(args, paramArg(p.Name)+" string")
+	}
+	if op.Req != nil {
+		args = append(args, "body "+reqType)
+	}
+
+	fmt.Fprintf(b, "func (c *Client) %s(%s) (*%s, error) {\n", op.Name, strings.Join(args, ", "), respType)
+	fmt.Fprintf(b, "\tpath := %s\n", pathExpr(op))
+
+	hasQuery := false
+	for _, p := range op.Params {
+		if p.Location == "query" {
+			hasQuery = true
+		}
+	}
+	if hasQuery {
+		b.WriteString("\tq := url.Values{}\n")
+		for _, p := range op.Params {
+			if p.Location == "query" {
+				fmt.Fprintf(b, "\tq.Set(%q, %s)\n", p.Name, paramArg(p.Name))
+			}
+		}
+		b.WriteString("\tif encoded := q.Encode(); encoded != \"\" {\n\t\tpath += \"?\" + encoded\n\t}\n")
+	}
+
+	b.WriteString("\n\tvar bodyReader io.Reader\n")
+	if op.Req != nil {
+		fmt.Fprintf(b, "\tencoded, contentType, err := encodeBody(%q, body)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tbodyReader = bytes.NewReader(encoded)\n\n", string(op.Req.Content))
+	}
+
+	fmt.Fprintf(b, "\treq, err := http.NewRequestWithContext(ctx, %q, c.BaseURL+path, bodyReader)\n", op.Method)
+	b.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+	for _, p := range op.Params {
+		if p.Location == "header" {
+			fmt.Fprintf(b, "\treq.Header.Set(%q, %s)\n", p.Name, paramArg(p.Name))
+		}
+	}
+	if op.Req != nil {
+		b.WriteString("\treq.Header.Set(\"Content-Type\", contentType)\n")
+	}
+
+	b.WriteString("\n\tresp, err := c.HTTPClient.Do(req)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\tdefer resp.Body.Close()\n\n")
+	fmt.Fprintf(b, "\tif resp.StatusCode >= 400 {\n\t\tdata, _ := io.ReadAll(resp.Body)\n\t\treturn nil, fmt.Errorf(%q, resp.StatusCode, data)\n\t}\n\n", "unexpected status %d: %s")
+	fmt.Fprintf(b, "\tvar out %s\n", respType)
+	b.WriteString("\tif err := json.NewDecoder(resp.Body).Decode(&out); err != nil {\n\t\treturn nil, err\n\t}\n\n\treturn &out, nil\n}\n\n")
+}
+
+// paramArg returns the Go argument name used for a RouteParam, lowering
+// its first rune.
+func paramArg(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// pathExpr renders a Go string expression for op.Path, substituting each
+// recognized {name} path parameter with its bound argument.
+func pathExpr(op operation) string {
+	pathParams := map[string]bool{}
+	for _, p := range op.Params {
+		if p.Location == "path" {
+			pathParams[p.Name] = true
+		}
+	}
+
+	if !strings.Contains(op.Path, "{") {
+		return fmt.Sprintf("%q", op.Path)
+	}
+
+	var parts []string
+	var lit strings.Builder
+
+	for i := 0; i < len(op.Path); {
+		if op.Path[i] == '{' {
+			if j := strings.IndexByte(op.Path[i:], '}'); j > 0 {
+				name := op.Path[i+1 : i+j]
+				if pathParams[name] {
+					if lit.Len() > 0 {
+						parts = append(parts, fmt.Sprintf("%q", lit.String()))
+						lit.Reset()
+					}
+					parts = append(parts, paramArg(name))
+					i += j + 1
+					continue
+				}
+			}
+		}
+		lit.WriteByte(op.Path[i])
+		i++
+	}
+	if lit.Len() > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%q", lit.String()))
+	}
+
+	return strings.Join(parts, " + ")
+}
@@ -0,0 +1,193 @@
+// Package codegen turns a built *spec.OpenAPI into idiomatic Go source: a
+// typed HTTP client (Client) and, via ServerStubs, server-side routing
+// glue for a handful of common routers. It reads spec.OpenAPI.Routes
+// (populated by spec.AddRoute, AddParam, AddResp and AddReqBody) rather
+// than re-deriving anything from Paths, so generated code always matches
+// what the builder recorded.
+package codegen
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+// Router selects which server-side routing library ServerStubs targets.
+type Router string
+
+const (
+	NetHTTP    Router = "net/http"
+	Chi        Router = "chi"
+	GorillaMux Router = "gorilla/mux"
+	Echo       Router = "echo"
+)
+
+// Generator renders Go source for the routes recorded on an *spec.OpenAPI.
+type Generator struct {
+	Package string // package name emitted at the top of every generated file
+	api     *spec.OpenAPI
+}
+
+// New returns a Generator for api, emitting Go source into the named
+// package.
+func New(api *spec.OpenAPI, pkg string) *Generator {
+	return &Generator{Package: pkg, api: api}
+}
+
+// operation is the codegen-friendly view of a single route.
+type operation struct {
+	Name   string
+	Method string
+	Path   string
+	Tag    string
+	Desc   string
+	Params []spec.RouteParam
+	Req    *spec.RouteReq
+	Resp   *spec.RouteResp
+}
+
+// operations returns every route on the generator's spec, sorted by path
+// then method so generated output is stable across runs.
+func (g *Generator) operations() []operation {
+	ops := make([]operation, 0, len(g.api.Routes))
+
+	for ur, route := range g.api.Routes {
+		op := operation{
+			Name:   operationName(ur),
+			Method: strings.ToUpper(string(ur.Method)),
+			Path:   ur.Path,
+			Tag:    normTag(route.Tag),
+			Desc:   route.Desc,
+		}
+
+		for _, p := range route.Params {
+			op.Params = append(op.Params, p)
+		}
+		sort.Slice(op.Params, func(i, j int) bool { return op.Params[i].Name < op.Params[j].Name })
+
+		codes := make([]string, 0, len(route.Requests))
+		for content := range route.Requests {
+			codes = append(codes, content)
+		}
+		sort.Strings(codes)
+		if len(codes) > 0 {
+			req := route.Requests[codes[0]]
+			op.Req = &req
+		}
+
+		statuses := make([]string, 0, len(route.Responses))
+		for status := range route.Responses {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		if len(statuses) > 0 {
+			resp := route.Responses[statuses[0]]
+			op.Resp = &resp
+		}
+
+		ops = append(ops, op)
+	}
+
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+
+	return ops
+}
+
+// operationName derives an exported Go identifier for a route, e.g.
+// GET /users/{id} -> "GetUsersId".
+func operationName(ur spec.UniqueRoute) string {
+	var b strings.Builder
+	b.WriteString(exportName(strings.ToLower(string(ur.Method))))
+
+	for _, seg := range strings.Split(ur.Path, "/") {
+		seg = strings.NewReplacer("{", "", "}", "").Replace(seg)
+		if seg == "" {
+			continue
+		}
+		b.WriteString(exportName(seg))
+	}
+
+	return b.String()
+}
+
+// normTag returns tag, or spec.Default if it is empty, matching how
+// spec.AddRoute defaults an empty tag.
+func normTag(tag string) string {
+	if tag == "" {
+		return spec.Default
+	}
+	return tag
+}
+
+// exportName turns s (a path segment or JSON property name, neither of
+// which is guaranteed to be a valid Go identifier) into one: each run of
+// characters illegal in a Go identifier is treated as a word boundary and
+// dropped, with the next rune upper-cased, so e.g. "user-profiles"
+// becomes "UserProfiles" and "user_id" becomes "UserId". A result that
+// would start with a digit (e.g. a property named "2fa") is prefixed with
+// an underscore.
+func exportName(s string) string {
+	var b strings.Builder
+	upperNext := true
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if upperNext {
+				b.WriteRune(unicode.ToUpper(r))
+				upperNext = false
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		upperNext = true
+	}
+
+	out := b.String()
+	if out != "" && unicode.IsDigit(rune(out[0])) {
+		out = "_" + out
+	}
+	return out
+}
+
+// refName extracts the Go type name referenced by a components/schemas
+// $ref, e.g. "#/components/schemas/User" -> "User".
+func refName(ref string) string {
+	i := strings.LastIndex(ref, "/")
+	if i < 0 {
+		return ref
+	}
+	return ref[i+1:]
+}
+
+// goTypeForRef returns the Go type used to reference a RouteReq/RouteResp:
+// the referenced component name, or "any" when there is no named schema,
+// wrapped in a slice when array is set.
+func goTypeForRef(ref spec.Reference, array bool) string {
+	t := "any"
+	if ref != "" {
+		t = refName(string(ref))
+	}
+	if array {
+		return "[]" + t
+	}
+	return t
+}
+
+// formatSource runs gofmt over generated source, returning src unformatted
+// alongside the error if it doesn't parse.
+func formatSource(src string) (string, error) {
+	b, err := format.Source([]byte(src))
+	if err != nil {
+		return src, fmt.Errorf("codegen: formatting generated source: %w", err)
+	}
+	return string(b), nil
+}
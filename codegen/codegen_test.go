@@ -0,0 +1,118 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+type upload struct {
+	Name string `json:"name"`
+}
+
+type withTimestamps struct {
+	Times []time.Time `json:"times"`
+}
+
+type withHyphenatedField struct {
+	UserID string `json:"user-id"`
+}
+
+func newTestAPI(t *testing.T, content spec.MIMEType) *spec.OpenAPI {
+	t.Helper()
+
+	api := spec.New("t", "1.0", "")
+	ur, err := api.AddRoute("/uploads", "post", "uploads", "upload a file", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if err := api.AddReqBody(ur, spec.NewBody(content, "", "", false, upload{})); err != nil {
+		t.Fatalf("AddReqBody: %v", err)
+	}
+	if err := api.AddResp(ur, spec.NewBody(spec.Json, "200", "", false, upload{})); err != nil {
+		t.Fatalf("AddResp: %v", err)
+	}
+
+	return api
+}
+
+func TestClientEncodesMultipartWithBoundary(t *testing.T) {
+	src, err := New(newTestAPI(t, spec.Form), "client").Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	if !strings.Contains(src, "encodeBody(\"multipart/form-data\", body)") {
+		t.Fatalf("expected a multipart encodeBody call, got:\n%s", src)
+	}
+	if !strings.Contains(src, "w.FormDataContentType()") {
+		t.Fatalf("expected the multipart branch to use FormDataContentType for the boundary, got:\n%s", src)
+	}
+	if !strings.Contains(src, "req.Header.Set(\"Content-Type\", contentType)") {
+		t.Fatalf("expected the method to send encodeBody's returned Content-Type, got:\n%s", src)
+	}
+}
+
+func TestClientEncodesURLEncodedForm(t *testing.T) {
+	src, err := New(newTestAPI(t, spec.XForm), "client").Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	if !strings.Contains(src, "encodeBody(\"application/x-www-form-urlencoded\", body)") {
+		t.Fatalf("expected a form-urlencoded encodeBody call, got:\n%s", src)
+	}
+}
+
+func TestOperationNameSanitizesKebabCasePathSegments(t *testing.T) {
+	api := spec.New("t", "1.0", "")
+	ur, err := api.AddRoute("/user-profiles/{id}", "get", "users", "", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+
+	src, err := New(api, "client").Client()
+	if err != nil {
+		t.Fatalf("Client: %v", err)
+	}
+
+	if !strings.Contains(src, "func (c *Client) "+operationName(ur)+"(") {
+		t.Fatalf("expected a %s method in the generated client, got:\n%s", operationName(ur), src)
+	}
+	if operationName(ur) != "GetUserProfilesId" {
+		t.Fatalf("expected operationName to sanitize kebab-case segments to GetUserProfilesId, got %q", operationName(ur))
+	}
+}
+
+func TestStructsSanitizesHyphenatedFieldNames(t *testing.T) {
+	api := spec.New("t", "1.0", "")
+	api.SchemaFor(withHyphenatedField{})
+
+	src, err := New(api, "models").Structs()
+	if err != nil {
+		t.Fatalf("Structs: %v", err)
+	}
+
+	if !strings.Contains(src, `UserId string `) {
+		t.Fatalf("expected the user-id field to render as the Go identifier UserId, got:\n%s", src)
+	}
+}
+
+func TestStructsImportsTimeForSliceField(t *testing.T) {
+	api := spec.New("t", "1.0", "")
+	api.SchemaFor(withTimestamps{})
+
+	src, err := New(api, "models").Structs()
+	if err != nil {
+		t.Fatalf("Structs: %v", err)
+	}
+
+	if !strings.Contains(src, `"time"`) {
+		t.Fatalf("expected generated source to import \"time\" for a []time.Time field, got:\n%s", src)
+	}
+	if !strings.Contains(src, "[]time.Time") {
+		t.Fatalf("expected the Times field to render as []time.Time, got:\n%s", src)
+	}
+}
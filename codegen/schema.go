@@ -0,0 +1,119 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+// goType returns the Go type used to represent s in generated structs and
+// client method signatures.
+func goType(s *spec.Schema) string {
+	if s == nil {
+		return "any"
+	}
+	if s.Ref != "" {
+		return refName(s.Ref)
+	}
+
+	switch s.Type {
+	case spec.String.String():
+		switch s.Format {
+		case "date-time", "dateTime":
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		default:
+			return "string"
+		}
+
+	case spec.Integer.String():
+		if s.Format == spec.Int64.String() {
+			return "int64"
+		}
+		return "int32"
+
+	case spec.Number.String():
+		if s.Format == spec.Float.String() {
+			return "float32"
+		}
+		return "float64"
+
+	case spec.Boolean.String():
+		return "bool"
+
+	case spec.Array.String():
+		return "[]" + goType(s.Items)
+
+	case spec.Object.String():
+		if s.AdditionalProperties != nil {
+			return "map[string]" + goType(s.AdditionalProperties)
+		}
+		return "map[string]any"
+
+	default:
+		return "any"
+	}
+}
+
+// Structs renders one Go struct per entry in the spec's
+// components/schemas, named exactly as the entry and with exported fields,
+// the request/response types referenced by the generated client and
+// server stubs.
+func (g *Generator) Structs() (string, error) {
+	names := make([]string, 0, len(g.api.Components.Schemas))
+	for name := range g.api.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var body strings.Builder
+	usesTime := false
+
+	for _, name := range names {
+		s := g.api.Components.Schemas[name]
+
+		if s.Desc != "" {
+			fmt.Fprintf(&body, "// %s %s\n", name, s.Desc)
+		}
+		fmt.Fprintf(&body, "type %s struct {\n", name)
+
+		fields := make([]string, 0, len(s.Properties))
+		for field := range s.Properties {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+
+		required := map[string]bool{}
+		for _, r := range s.Required {
+			required[r] = true
+		}
+
+		for _, field := range fields {
+			gt := goType(s.Properties[field])
+			if strings.Contains(gt, "time.Time") {
+				usesTime = true
+			}
+
+			omitempty := ",omitempty"
+			if required[field] {
+				omitempty = ""
+			}
+
+			fmt.Fprintf(&body, "\t%s %s `json:\"%s%s\"`\n", exportName(field), gt, field, omitempty)
+		}
+
+		body.WriteString("}\n\n")
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "package %s\n\n", g.Package)
+	if usesTime {
+		out.WriteString("import \"time\"\n\n")
+	}
+	out.WriteString(body.String())
+
+	return formatSource(out.String())
+}
@@ -0,0 +1,142 @@
+package codegen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ServerStubs renders one Handler interface per tag (one method per route
+// on that tag) plus a RegisterRoutes function wiring every route onto the
+// given router. Each handler method receives the raw
+// http.ResponseWriter/*http.Request pair and is responsible for decoding
+// its own request; see the validate sub-package for a middleware-based
+// alternative that decodes and validates against the spec automatically.
+func (g *Generator) ServerStubs(router Router) (string, error) {
+	ops := g.operations()
+
+	byTag := map[string][]operation{}
+	for _, op := range ops {
+		byTag[op.Tag] = append(byTag[op.Tag], op)
+	}
+
+	tags := make([]string, 0, len(byTag))
+	for t := range byTag {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", g.Package)
+	writeServerImports(&b, router)
+
+	for _, tag := range tags {
+		iface := exportName(tag) + "Handler"
+		fmt.Fprintf(&b, "// %s is implemented by the %s operation handlers.\n", iface, tag)
+		fmt.Fprintf(&b, "type %s interface {\n", iface)
+		for _, op := range byTag[tag] {
+			fmt.Fprintf(&b, "\t%s(w http.ResponseWriter, r *http.Request)\n", op.Name)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	writeRegisterFunc(&b, router, byTag, tags)
+
+	return formatSource(b.String())
+}
+
+func writeServerImports(b *strings.Builder, router Router) {
+	switch router {
+	case Chi:
+		b.WriteString("import (\n\t\"net/http\"\n\n\t\"github.com/go-chi/chi/v5\"\n)\n\n")
+	case GorillaMux:
+		b.WriteString("import (\n\t\"net/http\"\n\n\t\"github.com/gorilla/mux\"\n)\n\n")
+	case Echo:
+		b.WriteString("import (\n\t\"net/http\"\n\n\t\"github.com/labstack/echo/v4\"\n)\n\n")
+	default:
+		b.WriteString("import \"net/http\"\n\n")
+	}
+}
+
+// writeRegisterFunc appends a RegisterRoutes(<router>, <one handler per
+// tag>) function wiring every operation onto router.
+func writeRegisterFunc(b *strings.Builder, router Router, byTag map[string][]operation, tags []string) {
+	switch router {
+	case Chi:
+		b.WriteString("// RegisterRoutes wires every operation onto a chi.Router.\n")
+		b.WriteString("func RegisterRoutes(r chi.Router")
+		writeHandlerArgs(b, tags)
+		b.WriteString(") {\n")
+		for _, tag := range tags {
+			for _, op := range byTag[tag] {
+				fmt.Fprintf(b, "\tr.Method(%q, %q, http.HandlerFunc(%s.%s))\n", op.Method, op.Path, handlerArg(tag), op.Name)
+			}
+		}
+		b.WriteString("}\n")
+
+	case GorillaMux:
+		b.WriteString("// RegisterRoutes wires every operation onto a mux.Router.\n")
+		b.WriteString("func RegisterRoutes(r *mux.Router")
+		writeHandlerArgs(b, tags)
+		b.WriteString(") {\n")
+		for _, tag := range tags {
+			for _, op := range byTag[tag] {
+				fmt.Fprintf(b, "\tr.HandleFunc(%q, %s.%s).Methods(%q)\n", op.Path, handlerArg(tag), op.Name, op.Method)
+			}
+		}
+		b.WriteString("}\n")
+
+	case Echo:
+		b.WriteString("// RegisterRoutes wires every operation onto an echo.Echo.\n")
+		b.WriteString("func RegisterRoutes(e *echo.Echo")
+		writeHandlerArgs(b, tags)
+		b.WriteString(") {\n")
+		for _, tag := range tags {
+			for _, op := range byTag[tag] {
+				fmt.Fprintf(b, "\te.Add(%q, %q, func(c echo.Context) error {\n\t\t%s.%s(c.Response(), c.Request())\n\t\treturn nil\n\t})\n", op.Method, op.Path, handlerArg(tag), op.Name)
+			}
+		}
+		b.WriteString("}\n")
+
+	default: // net/http
+		b.WriteString("// RegisterRoutes wires every operation onto an http.ServeMux. Routes\n")
+		b.WriteString("// sharing a path are dispatched by method from a single handler, since\n")
+		b.WriteString("// http.ServeMux cannot register the same pattern twice.\n")
+		b.WriteString("func RegisterRoutes(mux *http.ServeMux")
+		writeHandlerArgs(b, tags)
+		b.WriteString(") {\n")
+
+		byPath := map[string][]operation{}
+		var paths []string
+		for _, tag := range tags {
+			for _, op := range byTag[tag] {
+				if _, ok := byPath[op.Path]; !ok {
+					paths = append(paths, op.Path)
+				}
+				byPath[op.Path] = append(byPath[op.Path], op)
+			}
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			fmt.Fprintf(b, "\tmux.HandleFunc(%q, func(w http.ResponseWriter, r *http.Request) {\n\t\tswitch r.Method {\n", path)
+			for _, op := range byPath[path] {
+				fmt.Fprintf(b, "\t\tcase %q:\n\t\t\t%s.%s(w, r)\n", op.Method, handlerArg(op.Tag), op.Name)
+			}
+			b.WriteString("\t\tdefault:\n\t\t\thttp.Error(w, \"method not allowed\", http.StatusMethodNotAllowed)\n\t\t}\n\t})\n")
+		}
+		b.WriteString("}\n")
+	}
+}
+
+func writeHandlerArgs(b *strings.Builder, tags []string) {
+	for _, tag := range tags {
+		fmt.Fprintf(b, ", %s %sHandler", handlerArg(tag), exportName(tag))
+	}
+}
+
+// handlerArg returns the RegisterRoutes parameter name for a tag's
+// handler, lowering its first rune.
+func handlerArg(tag string) string {
+	return paramArg(tag)
+}
@@ -2,16 +2,24 @@ package spec
 
 import (
 	"fmt"
-	"reflect"
 
 	jsoniter "github.com/json-iterator/go"
 )
 
 const Default = "default"
 
+// Supported target versions for the generated spec; see New and New31.
+const (
+	Version30 = "3.0.3"
+	Version31 = "3.1.0"
+
+	// Dialect202012 is the default JSON Schema dialect declared by New31.
+	Dialect202012 = "https://spec.openapis.org/oas/3.1/dialect/base"
+)
+
 func New(title, version, description string) *OpenAPI {
 	return &OpenAPI{
-		Version: "3.0.3",
+		Version: Version30,
 		Info: Info{
 			Title:   title,
 			Version: version,
@@ -20,9 +28,21 @@ func New(title, version, description string) *OpenAPI {
 		Tags:         make([]Tag, 0),
 		Paths:        map[string]OperationMap{}, // a map of methods mapped to operations i.e., get, put, post, delete
 		ExternalDocs: &ExternalDocs{},
+		Routes:       map[UniqueRoute]Route{},
 	}
 }
 
+// New31 is the OpenAPI 3.1 / JSON Schema 2020-12 counterpart to New. The
+// returned OpenAPI renders with nullable unions, numeric
+// exclusiveMinimum/exclusiveMaximum, examples arrays and the other 3.1
+// output differences handled by OpenAPI.JSON; see schemaFor31.
+func New31(title, version, description string) *OpenAPI {
+	o := New(title, version, description)
+	o.Version = Version31
+	o.JSONSchemaDialect = Dialect202012
+	return o
+}
+
 // key is the reference name for the open api spec
 type Requests map[string]RequestBody
 type Params map[string]Param
@@ -33,6 +53,7 @@ type RouteParam struct {
 	Required bool   // is this paramater required
 	Location string // REQUIRED. The location of the parameter. Possible values are "query", "header", "path" or "cookie".
 	Example  map[string]any
+	Schema   *Schema // format/type/enum/minimum/maximum/pattern constraints; validated by the validate sub-package
 }
 
 type Method string
@@ -248,19 +269,28 @@ func (o *OpenAPI) AddParam(ur UniqueRoute, rp RouteParam) error {
 	}
 
 	m.Params = append(m.Params, Param{
-		Name: rp.Name,
-		Desc: rp.Desc,
-		In:   rp.Location,
+		Name:     rp.Name,
+		Desc:     rp.Desc,
+		In:       rp.Location,
+		Required: rp.Required,
+		Schema:   rp.Schema,
 	})
 
 	p[ur.Method] = m
 	o.Paths[ur.Path] = p
 
+	route := o.Routes[ur]
+	if route.Params == nil {
+		route.Params = map[string]RouteParam{}
+	}
+	route.Params[rp.Name] = rp
+	o.Routes[ur] = route
+
 	return nil
 }
 
-// AddResp adds response information to the api responses map
-// this is used for a request body, response body
+// AddResp adds response information to the api responses map, building the
+// response body's schema from bo.Body via reflection.
 func (o *OpenAPI) AddResp(ur UniqueRoute, bo BodyObject) error {
 
 	p, found := o.Paths[ur.Path]
@@ -272,61 +302,104 @@ func (o *OpenAPI) AddResp(ur UniqueRoute, bo BodyObject) error {
 		return fmt.Errorf("could not find method to add param %v", ur)
 	}
 
-	schema := Schema{
-		Title: bo.Title,
-		Desc:  bo.Desc,
+	schema := o.SchemaFor(bo.Body)
+	ref := schema.Ref
+	if schema.Ref == "" {
+		schema.Title = bo.Title
+		schema.Desc = bo.Desc
 	}
-
-	t := reflect.TypeOf(bo.Body)
-	k := t.Kind()
-	switch k {
-	case reflect.String:
-		schema.Type = String.String()
-
-	case reflect.Array, reflect.Slice:
-		schema.Type = Array.String()
-		schema.Items = &Schema{}
+	if bo.Array {
+		schema = &Schema{Type: Array.String(), Items: schema, Title: bo.Title, Desc: bo.Desc}
 	}
 
-	m.Responses = Responses{
-		bo.HttpStatus: Response{
-			Desc: bo.Desc,
-			Content: map[string]Media{
-				string(bo.MIMEType): Media{
-					Schema: Schema{
-						Title: bo.Title,
-						Desc:  bo.Desc,
-					},
-				},
-			},
+	if m.Responses == nil {
+		m.Responses = Responses{}
+	}
+	m.Responses[bo.HttpStatus] = Response{
+		Desc: bo.Desc,
+		Content: map[string]Media{
+			string(bo.MIMEType): {Schema: *schema},
 		},
 	}
 
+	p[ur.Method] = m
+	o.Paths[ur.Path] = p
+
+	route := o.Routes[ur]
+	if route.Responses == nil {
+		route.Responses = map[string]RouteResp{}
+	}
+	route.Responses[bo.HttpStatus] = RouteResp{
+		Code:    bo.HttpStatus,
+		Content: bo.MIMEType,
+		Ref:     Reference(ref),
+		Array:   bo.Array,
+	}
+	o.Routes[ur] = route
+
 	return nil
 }
 
-func (pr Properties) Construct(item any) {
-	t := reflect.TypeOf(item)
-	v := reflect.ValueOf(item)
-	k := v.Kind()
+// AddReqBody adds request body information to the api requestBody object,
+// building the request body's schema from bo.Body via reflection.
+func (o *OpenAPI) AddReqBody(ur UniqueRoute, bo BodyObject) error {
+	p, found := o.Paths[ur.Path]
+	if !found {
+		return fmt.Errorf("could not find path to add param %v", ur)
+	}
+	m, found := p[ur.Method]
+	if !found {
+		return fmt.Errorf("could not find method to add param %v", ur)
+	}
 
-	switch k {
-	case reflect.Slice:
-		t = reflect.SliceOf(t)
-	case reflect.Array:
+	schema := o.SchemaFor(bo.Body)
+	ref := schema.Ref
+	if schema.Ref == "" {
+		schema.Title = bo.Title
+		schema.Desc = bo.Desc
+	}
+	if bo.Array {
+		schema = &Schema{Type: Array.String(), Items: schema, Title: bo.Title, Desc: bo.Desc}
+	}
 
+	m.RequestBody = &RequestBody{
+		Desc:     bo.Desc,
+		Required: true,
+		Content: map[string]Media{
+			string(bo.MIMEType): {Schema: *schema},
+		},
 	}
 
-}
+	p[ur.Method] = m
+	o.Paths[ur.Path] = p
+
+	route := o.Routes[ur]
+	if route.Requests == nil {
+		route.Requests = map[string]RouteReq{}
+	}
+	route.Requests[string(bo.MIMEType)] = RouteReq{
+		Content: bo.MIMEType,
+		Ref:     Reference(ref),
+		Array:   bo.Array,
+	}
+	o.Routes[ur] = route
 
-// AddReq adds request information to the api requestBody object
-func (o *OpenAPI) AddReqBody(ur UniqueRoute, bo BodyObject) error {
 	return nil
 }
 
-// JSON returns the json string value for the OpenAPI object
+// JSON returns the json string value for the OpenAPI object. Documents
+// whose Version is 3.1.x are rendered through render31 first so that the
+// 3.0/3.1 output differences (nullable unions, examples arrays, numeric
+// exclusiveMinimum/exclusiveMaximum) come out correctly for the declared
+// version.
 func (o *OpenAPI) JSON() string {
 	json := jsoniter.ConfigFastest
-	b, _ := json.Marshal(o)
+
+	if is31(o.Version) {
+		b, _ := json.Marshal(o.render31())
+		return string(b)
+	}
+
+	b, _ := json.Marshal(o.render30())
 	return string(b)
 }
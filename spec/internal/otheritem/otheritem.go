@@ -0,0 +1,8 @@
+// Package otheritem exists only so spec's reflection tests can exercise a
+// named type that collides, by bare name, with a type of the same name
+// declared in a different package.
+package otheritem
+
+type Item struct {
+	Label string `json:"label"`
+}
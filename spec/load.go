@@ -0,0 +1,88 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// DocFormat selects the serialization Load/LoadFile parses. It is
+// distinct from Format, which describes a Schema's numeric/string format
+// keyword.
+type DocFormat string
+
+const (
+	FormatJSON DocFormat = "json"
+	FormatYAML DocFormat = "yaml"
+)
+
+// Load parses an OpenAPI document from r in the given format. YAML input
+// is decoded generically first and re-marshaled as JSON so the same json
+// struct tags drive both formats.
+func Load(r io.Reader, format DocFormat) (*OpenAPI, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("spec: reading document: %w", err)
+	}
+
+	jsonData := data
+	if format == FormatYAML {
+		var generic any
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return nil, fmt.Errorf("spec: parsing yaml document: %w", err)
+		}
+		if jsonData, err = json.Marshal(generic); err != nil {
+			return nil, fmt.Errorf("spec: converting yaml document to json: %w", err)
+		}
+	}
+
+	o := &OpenAPI{Routes: map[UniqueRoute]Route{}}
+	if err := json.Unmarshal(jsonData, o); err != nil {
+		return nil, fmt.Errorf("spec: parsing document: %w", err)
+	}
+
+	return o, nil
+}
+
+// LoadFile reads and parses the OpenAPI document at path, inferring its
+// format from the file extension (.yaml/.yml for YAML, everything else as
+// JSON).
+func LoadFile(path string) (*OpenAPI, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("spec: opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return Load(f, formatForPath(path))
+}
+
+func formatForPath(path string) DocFormat {
+	ext := strings.ToLower(path[strings.LastIndex(path, ".")+1:])
+	if ext == "yaml" || ext == "yml" {
+		return FormatYAML
+	}
+	return FormatJSON
+}
+
+// YAML returns the YAML string value for the OpenAPI object, the
+// YAML-emitting counterpart to JSON. It renders through JSON first so
+// version-specific output (see render31) and Schema's custom JSON
+// marshaling stay the single source of truth for both formats.
+func (o *OpenAPI) YAML() (string, error) {
+	var generic any
+	if err := json.Unmarshal([]byte(o.JSON()), &generic); err != nil {
+		return "", fmt.Errorf("spec: rendering yaml: %w", err)
+	}
+
+	b, err := yaml.Marshal(generic)
+	if err != nil {
+		return "", fmt.Errorf("spec: rendering yaml: %w", err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,89 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+const loadTestJSON = `{
+  "openapi": "3.0.3",
+  "info": {"title": "t", "version": "1.0"},
+  "paths": {
+    "/widgets": {
+      "get": {"responses": {"200": {"description": "ok"}}}
+    }
+  }
+}`
+
+const loadTestYAML = `
+openapi: "3.0.3"
+info:
+  title: t
+  version: "1.0"
+paths:
+  /widgets:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+func TestLoadParsesJSON(t *testing.T) {
+	o, err := Load(strings.NewReader(loadTestJSON), FormatJSON)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if o.Info.Title != "t" {
+		t.Fatalf("expected title %q, got %q", "t", o.Info.Title)
+	}
+	if _, ok := o.Paths["/widgets"]["get"]; !ok {
+		t.Fatalf("expected /widgets get to be parsed, got %+v", o.Paths)
+	}
+}
+
+func TestLoadParsesYAML(t *testing.T) {
+	o, err := Load(strings.NewReader(loadTestYAML), FormatYAML)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if o.Info.Title != "t" {
+		t.Fatalf("expected title %q, got %q", "t", o.Info.Title)
+	}
+	if _, ok := o.Paths["/widgets"]["get"]; !ok {
+		t.Fatalf("expected /widgets get to be parsed, got %+v", o.Paths)
+	}
+}
+
+func TestYAMLRoundTripsJSON(t *testing.T) {
+	o, err := Load(strings.NewReader(loadTestJSON), FormatJSON)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	y, err := o.YAML()
+	if err != nil {
+		t.Fatalf("YAML: %v", err)
+	}
+
+	reloaded, err := Load(strings.NewReader(y), FormatYAML)
+	if err != nil {
+		t.Fatalf("Load(YAML): %v", err)
+	}
+	if reloaded.Info.Title != o.Info.Title {
+		t.Fatalf("expected title to round-trip, got %q", reloaded.Info.Title)
+	}
+}
+
+func TestFormatForPath(t *testing.T) {
+	cases := map[string]DocFormat{
+		"spec.yaml": FormatYAML,
+		"spec.yml":  FormatYAML,
+		"spec.json": FormatJSON,
+		"spec":      FormatJSON,
+	}
+	for path, want := range cases {
+		if got := formatForPath(path); got != want {
+			t.Errorf("formatForPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
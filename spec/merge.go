@@ -0,0 +1,480 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Fetcher retrieves the raw bytes of an external document referenced by a
+// $ref, e.g. over HTTP. MergeOptions.Fetcher must be set for Merge to
+// resolve "http://" or "https://" refs; local "./file.yaml#/..." refs are
+// always resolved relative to MergeOptions.BaseDir without one.
+type Fetcher func(location string) ([]byte, error)
+
+// MergeOptions controls how Merge combines two documents and how any
+// external $ref encountered along the way is resolved.
+type MergeOptions struct {
+	// OverwriteExisting makes other's paths, component schemas and
+	// security schemes win when the same name exists in both documents.
+	// The default keeps o's existing entry. Tags are matched by name and
+	// o's existing tag is always kept.
+	OverwriteExisting bool
+
+	// BaseDir resolves relative file refs such as "./common.yaml#/...".
+	// Defaults to the current working directory.
+	BaseDir string
+
+	// Fetcher resolves "http://"/"https://" ref locations. Remote refs
+	// fail if left nil.
+	Fetcher Fetcher
+}
+
+// Merge unions other into o: every tag, path, component schema and
+// security scheme from other is added to o, other's default security
+// requirements not already present in o are appended, and any external
+// $ref reachable from the merged document (including ones already
+// present in o) is resolved and inlined into o.Components.Schemas. When
+// the same path, method, schema or security scheme name exists in both
+// and opts.OverwriteExisting is false (the default), o's existing entry
+// wins.
+func (o *OpenAPI) Merge(other *OpenAPI, opts MergeOptions) error {
+	if other == nil {
+		return nil
+	}
+
+	for _, tag := range other.Tags {
+		if !hasTag(o.Tags, tag.Name) {
+			o.Tags = append(o.Tags, tag)
+		}
+	}
+
+	if o.Paths == nil {
+		o.Paths = Paths{}
+	}
+	for path, ops := range other.Paths {
+		cloned, err := cloneOperationMap(ops)
+		if err != nil {
+			return fmt.Errorf("spec: merging path %q: %w", path, err)
+		}
+
+		existing, found := o.Paths[path]
+		if !found {
+			o.Paths[path] = cloned
+			continue
+		}
+		for method, op := range cloned {
+			if _, has := existing[method]; has && !opts.OverwriteExisting {
+				continue
+			}
+			existing[method] = op
+		}
+		o.Paths[path] = existing
+	}
+
+	if len(other.Components.Schemas) > 0 {
+		if o.Components.Schemas == nil {
+			o.Components.Schemas = map[string]*Schema{}
+		}
+		for name, s := range other.Components.Schemas {
+			if _, has := o.Components.Schemas[name]; has && !opts.OverwriteExisting {
+				continue
+			}
+			cloned, err := cloneSchema(s)
+			if err != nil {
+				return fmt.Errorf("spec: merging schema %q: %w", name, err)
+			}
+			o.Components.Schemas[name] = cloned
+		}
+	}
+
+	if len(other.Components.SecuritySchemes) > 0 {
+		if o.Components.SecuritySchemes == nil {
+			o.Components.SecuritySchemes = map[string]*SecurityScheme{}
+		}
+		for name, s := range other.Components.SecuritySchemes {
+			if _, has := o.Components.SecuritySchemes[name]; has && !opts.OverwriteExisting {
+				continue
+			}
+			cloned, err := cloneSecurityScheme(s)
+			if err != nil {
+				return fmt.Errorf("spec: merging security scheme %q: %w", name, err)
+			}
+			o.Components.SecuritySchemes[name] = cloned
+		}
+	}
+
+	for _, req := range other.Security {
+		if !hasSecurityRequirement(o.Security, req) {
+			o.Security = append(o.Security, req)
+		}
+	}
+
+	return resolveRefs(o, opts)
+}
+
+// cloneSchema returns a deep copy of s via its JSON encoding, so Merge
+// never leaves o.Components.Schemas sharing pointers with the other
+// document it merged in - resolveRefs would otherwise rewrite other's
+// schemas in place.
+func cloneSchema(s *Schema) (*Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &Schema{}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// cloneOperationMap returns a deep copy of ops, for the same reason as
+// cloneSchema: Param.Schema, RequestBody.Content and Responses all nest
+// *Schema pointers that must not be shared with the document Merge read
+// ops from.
+func cloneOperationMap(ops OperationMap) (OperationMap, error) {
+	raw, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+
+	out := OperationMap{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// cloneSecurityScheme returns a deep copy of s via its JSON encoding, for
+// the same reason as cloneSchema: SecurityScheme.Flows nests pointers
+// that must not be shared with the document Merge read s from.
+func cloneSecurityScheme(s *SecurityScheme) (*SecurityScheme, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SecurityScheme{}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// hasSecurityRequirement reports whether req is already present in reqs,
+// used to dedupe other's default security requirements against o's when
+// merging.
+func hasSecurityRequirement(reqs []SecurityRequirement, req SecurityRequirement) bool {
+	for _, r := range reqs {
+		if reflect.DeepEqual(r, req) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTag(tags []Tag, name string) bool {
+	for _, t := range tags {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveRefs walks every schema reachable from o's components and path
+// operations, inlining any external $ref ("./common.yaml#/..." or, with
+// opts.Fetcher set, "https://.../common.yaml#/...") into
+// o.Components.Schemas and rewriting it to a local "#/components/schemas/..."
+// ref. Refs already local (starting with "#/") are left untouched.
+func resolveRefs(o *OpenAPI, opts MergeOptions) error {
+	r := &refResolver{
+		api:       o,
+		opts:      opts,
+		docs:      map[string]map[string]any{},
+		resolved:  map[string]string{},
+		resolving: map[string]bool{},
+	}
+
+	for name, s := range o.Components.Schemas {
+		if err := r.walk(s); err != nil {
+			return fmt.Errorf("spec: resolving $ref in schema %q: %w", name, err)
+		}
+	}
+
+	for path, methods := range o.Paths {
+		for method, op := range methods {
+			if err := r.walkOperation(&op); err != nil {
+				return fmt.Errorf("spec: resolving $ref in %s %s: %w", method, path, err)
+			}
+			methods[method] = op
+		}
+	}
+
+	return nil
+}
+
+// refResolver tracks parsed external documents, already-inlined refs and
+// in-flight resolutions across a single resolveRefs call, so a document
+// is never fetched twice, the same external ref is never inlined as two
+// separate components, and cycles are caught.
+type refResolver struct {
+	api       *OpenAPI
+	opts      MergeOptions
+	docs      map[string]map[string]any
+	resolved  map[string]string // location+"#"+fragment -> local ref, already inlined
+	resolving map[string]bool
+}
+
+// walk recurses into every nested schema field - the same set schemaFor31
+// (spec/v31.go) walks for 3.1 rendering - rewriting any external $ref it
+// finds along the way.
+func (r *refResolver) walk(s *Schema) error {
+	if s == nil {
+		return nil
+	}
+
+	if isExternalRef(s.Ref) {
+		local, err := r.resolveExternal(s.Ref)
+		if err != nil {
+			return err
+		}
+		s.Ref = local
+	}
+
+	if err := r.walk(s.Items); err != nil {
+		return err
+	}
+	if err := r.walk(s.AdditionalProperties); err != nil {
+		return err
+	}
+	if err := r.walk(s.If); err != nil {
+		return err
+	}
+	if err := r.walk(s.Then); err != nil {
+		return err
+	}
+	if err := r.walk(s.Else); err != nil {
+		return err
+	}
+	if err := r.walk(s.UnevaluatedProperties); err != nil {
+		return err
+	}
+	for _, p := range s.Properties {
+		if err := r.walk(p); err != nil {
+			return err
+		}
+	}
+	for _, p := range s.PrefixItems {
+		if err := r.walk(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *refResolver) walkOperation(op *Operation) error {
+	for _, p := range op.Params {
+		if err := r.walk(p.Schema); err != nil {
+			return err
+		}
+	}
+
+	if op.RequestBody != nil {
+		for mime, media := range op.RequestBody.Content {
+			if err := r.walk(&media.Schema); err != nil {
+				return err
+			}
+			op.RequestBody.Content[mime] = media
+		}
+	}
+
+	for status, resp := range op.Responses {
+		for mime, media := range resp.Content {
+			if err := r.walk(&media.Schema); err != nil {
+				return err
+			}
+			resp.Content[mime] = media
+		}
+		op.Responses[status] = resp
+	}
+
+	return nil
+}
+
+// isExternalRef reports whether ref points outside the current document,
+// i.e. it is not a bare "#/..." JSON pointer.
+func isExternalRef(ref string) bool {
+	return ref != "" && !strings.HasPrefix(ref, "#/")
+}
+
+// resolveExternal fetches and inlines the schema at ref into
+// r.api.Components.Schemas, returning the local ref that now points at it.
+func (r *refResolver) resolveExternal(ref string) (string, error) {
+	location, fragment := splitRef(ref)
+
+	key := location + "#" + fragment
+	if local, ok := r.resolved[key]; ok {
+		return local, nil
+	}
+	if r.resolving[key] {
+		return "", fmt.Errorf("cycle detected resolving %s", ref)
+	}
+	r.resolving[key] = true
+	defer delete(r.resolving, key)
+
+	doc, err := r.loadDoc(location)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := lookupPointer(doc, fragment)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+
+	s := &Schema{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+	if err := r.walk(s); err != nil {
+		return "", err
+	}
+
+	if r.api.Components.Schemas == nil {
+		r.api.Components.Schemas = map[string]*Schema{}
+	}
+	name := uniqueSchemaName(r.api.Components.Schemas, refName(fragment))
+	r.api.Components.Schemas[name] = s
+
+	local := "#/components/schemas/" + name
+	r.resolved[key] = local
+
+	return local, nil
+}
+
+// loadDoc fetches and parses the document at location, caching the result
+// for the rest of this resolution pass.
+func (r *refResolver) loadDoc(location string) (map[string]any, error) {
+	if doc, ok := r.docs[location]; ok {
+		return doc, nil
+	}
+
+	var data []byte
+	var err error
+
+	switch {
+	case strings.HasPrefix(location, "http://"), strings.HasPrefix(location, "https://"):
+		if r.opts.Fetcher == nil {
+			return nil, fmt.Errorf("no Fetcher configured to resolve remote ref %q", location)
+		}
+		data, err = r.opts.Fetcher(location)
+	default:
+		path := location
+		if r.opts.BaseDir != "" && !filepath.IsAbs(path) {
+			path = filepath.Join(r.opts.BaseDir, path)
+		}
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", location, err)
+	}
+
+	var generic any
+	if strings.HasSuffix(location, ".yaml") || strings.HasSuffix(location, ".yml") {
+		err = yaml.Unmarshal(data, &generic)
+	} else {
+		err = json.Unmarshal(data, &generic)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", location, err)
+	}
+
+	doc, ok := generic.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected an object at the document root", location)
+	}
+
+	r.docs[location] = doc
+	return doc, nil
+}
+
+// lookupPointer walks a JSON-pointer-style fragment (e.g.
+// "/components/schemas/Error") through doc.
+func lookupPointer(doc map[string]any, fragment string) (any, error) {
+	var cur any = doc
+	for _, seg := range strings.Split(strings.Trim(fragment, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("cannot descend into %q: not an object", seg)
+		}
+		cur, ok = m[seg]
+		if !ok {
+			return nil, fmt.Errorf("%q not found", seg)
+		}
+	}
+	return cur, nil
+}
+
+// splitRef splits a $ref into its external document location and
+// fragment, e.g. "./common.yaml#/components/schemas/Error" becomes
+// ("./common.yaml", "/components/schemas/Error").
+func splitRef(ref string) (location, fragment string) {
+	i := strings.Index(ref, "#")
+	if i < 0 {
+		return ref, ""
+	}
+	return ref[:i], ref[i+1:]
+}
+
+// refName returns the last path segment of a JSON-pointer fragment, e.g.
+// "/components/schemas/Error" becomes "Error".
+func refName(fragment string) string {
+	i := strings.LastIndex(fragment, "/")
+	if i < 0 {
+		return fragment
+	}
+	return fragment[i+1:]
+}
+
+func uniqueSchemaName(existing map[string]*Schema, name string) string {
+	if name == "" {
+		name = "Schema"
+	}
+	if _, ok := existing[name]; !ok {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s%d", name, i)
+		if _, ok := existing[candidate]; !ok {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package spec
+
+import "testing"
+
+func TestMergeUnionsSecuritySchemesAndDefaultSecurity(t *testing.T) {
+	o := New("t", "1.0", "")
+
+	other := New("other", "1.0", "")
+	other.AddAPIKeyAuth("apiKey", "header", "X-API-Key")
+	other.SetGlobalSecurity(SecurityRequirement{"apiKey": {}})
+
+	if err := o.Merge(other, MergeOptions{}); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	scheme, ok := o.Components.SecuritySchemes["apiKey"]
+	if !ok {
+		t.Fatalf("expected apiKey securityScheme to be merged, got %+v", o.Components.SecuritySchemes)
+	}
+	if scheme.Type != "apiKey" {
+		t.Fatalf("expected merged scheme type %q, got %q", "apiKey", scheme.Type)
+	}
+
+	if len(o.Security) != 1 || o.Security[0]["apiKey"] == nil {
+		t.Fatalf("expected other's default security requirement to be appended, got %+v", o.Security)
+	}
+}
+
+func TestMergeDoesNotDuplicateExistingSecurityRequirement(t *testing.T) {
+	o := New("t", "1.0", "")
+	o.SetGlobalSecurity(SecurityRequirement{"apiKey": {}})
+
+	other := New("other", "1.0", "")
+	other.SetGlobalSecurity(SecurityRequirement{"apiKey": {}})
+
+	if err := o.Merge(other, MergeOptions{}); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if len(o.Security) != 1 {
+		t.Fatalf("expected the duplicate security requirement to be deduped, got %+v", o.Security)
+	}
+}
+
+func TestMergeKeepsExistingSecuritySchemeWithoutOverwrite(t *testing.T) {
+	o := New("t", "1.0", "")
+	o.AddHTTPBearerAuth("apiKey", "JWT")
+
+	other := New("other", "1.0", "")
+	other.AddAPIKeyAuth("apiKey", "header", "X-API-Key")
+
+	if err := o.Merge(other, MergeOptions{}); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	if o.Components.SecuritySchemes["apiKey"].Type != "http" {
+		t.Fatalf("expected o's existing apiKey scheme to win, got %+v", o.Components.SecuritySchemes["apiKey"])
+	}
+}
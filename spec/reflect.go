@@ -0,0 +1,266 @@
+package spec
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry builds Schema objects from Go types via reflection. When
+// schemas is non-nil, named struct types are lifted into it and referenced
+// by $ref instead of being inlined; types records the schema name already
+// assigned to a given reflect.Type, both deduplicating repeated types
+// across calls and terminating cyclic types with a $ref instead of
+// recursing forever (the name is recorded before recursing into the
+// type's fields).
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	types   map[reflect.Type]string
+}
+
+// SchemaFor builds a complete Schema for v via reflection, lifting any
+// named struct type into components/schemas and referencing it by $ref so
+// that repeated or cyclic types are not inlined more than once. Two
+// distinct types sharing a bare name (e.g. same name, different packages)
+// are disambiguated rather than colliding on the same component.
+func (o *OpenAPI) SchemaFor(v any) *Schema {
+	if o.Components.Schemas == nil {
+		o.Components.Schemas = map[string]*Schema{}
+	}
+	if o.schemaTypes == nil {
+		o.schemaTypes = map[reflect.Type]string{}
+	}
+	reg := &schemaRegistry{
+		schemas: o.Components.Schemas,
+		types:   o.schemaTypes,
+	}
+	return reg.build(reflect.TypeOf(v))
+}
+
+// Construct populates pr with a Schema for every exported field of item,
+// recursing into nested structs, slices, maps and pointers. Named struct
+// types are inlined rather than lifted to components/schemas; use
+// OpenAPI.SchemaFor for the deduplicating, $ref-aware version.
+func (pr Properties) Construct(item any) {
+	t := reflect.TypeOf(item)
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	reg := &schemaRegistry{}
+	reg.populate(pr, t)
+}
+
+// build returns the Schema for t, dereferencing pointers and special-casing
+// time.Time and []byte before falling back to the Go kind.
+func (r *schemaRegistry) build(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		return &Schema{Type: String.String(), Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: String.String()}
+
+	case reflect.Bool:
+		return &Schema{Type: Boolean.String()}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: Integer.String(), Format: Int32.String()}
+
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: Integer.String(), Format: Int64.String()}
+
+	case reflect.Float32:
+		return &Schema{Type: Number.String(), Format: Float.String()}
+
+	case reflect.Float64:
+		return &Schema{Type: Number.String(), Format: Double.String()}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: String.String(), Format: Byte.String()}
+		}
+		return &Schema{Type: Array.String(), Items: r.build(t.Elem())}
+
+	case reflect.Map:
+		return &Schema{Type: Object.String(), AdditionalProperties: r.build(t.Elem())}
+
+	case reflect.Struct:
+		return r.buildNamed(t)
+
+	default:
+		return &Schema{Type: Object.String()}
+	}
+}
+
+// buildNamed builds the schema for a struct type, lifting it into
+// r.schemas and returning a $ref when it is a named type and the registry
+// has somewhere to put it; otherwise it is inlined. The schema name is
+// recorded in r.types before recursing into the type's fields, both so a
+// cyclic field referring back to t resolves to the same $ref instead of
+// recursing forever, and so a later call for the exact same t reuses it
+// rather than building (and naming) it again. Two distinct types that
+// share a bare name are disambiguated via uniqueSchemaName so neither
+// silently overwrites the other's component.
+func (r *schemaRegistry) buildNamed(t reflect.Type) *Schema {
+	name := t.Name()
+	if name == "" || t.PkgPath() == "" || r.schemas == nil {
+		return r.buildStruct(t)
+	}
+
+	if assigned, ok := r.types[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + assigned}
+	}
+
+	assigned := uniqueSchemaName(r.schemas, name)
+	r.types[t] = assigned
+	// reserve the name so a same-named type met among t's fields gets a
+	// distinct one
+	r.schemas[assigned] = &Schema{}
+
+	s := r.buildStruct(t)
+	s.Title = assigned
+	r.schemas[assigned] = s
+
+	return &Schema{Ref: "#/components/schemas/" + assigned}
+}
+
+// buildStruct builds an inline object Schema from a struct's exported
+// fields, honoring json and openapi tags.
+func (r *schemaRegistry) buildStruct(t reflect.Type) *Schema {
+	props := Properties{}
+	required := r.populate(props, t)
+
+	return &Schema{
+		Type:       Object.String(),
+		Properties: props,
+		Required:   required,
+	}
+}
+
+// populate fills props with a Schema per exported field of t (flattening
+// anonymous embedded structs) and returns the names of fields considered
+// required.
+func (r *schemaRegistry) populate(props Properties, t reflect.Type) []string {
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+
+		if f.Anonymous {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				required = append(required, r.populate(props, ft)...)
+				continue
+			}
+		}
+
+		name, omitempty, skip := jsonTagInfo(f)
+		if skip {
+			continue
+		}
+
+		s := r.build(f.Type)
+		isRequired := !omitempty && f.Type.Kind() != reflect.Ptr
+		if tag, ok := f.Tag.Lookup("openapi"); ok {
+			isRequired = applyOpenAPITag(s, tag, isRequired)
+		}
+
+		props[name] = s
+		if isRequired {
+			required = append(required, name)
+		}
+	}
+
+	return required
+}
+
+// jsonTagInfo extracts the effective property name, whether omitempty was
+// set, and whether the field is excluded entirely (json:"-").
+func jsonTagInfo(f reflect.StructField) (name string, omitempty, skip bool) {
+	name = f.Name
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return name, false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return name, false, true
+	}
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	return name, omitempty, false
+}
+
+// applyOpenAPITag parses a struct tag of the form
+// `openapi:"format=uuid,example=foo,description=...,minimum=1,maximum=100,enum=a|b|c,required=true"`
+// onto s, and returns the (possibly overridden) required state for the
+// field.
+func applyOpenAPITag(s *Schema, tag string, required bool) bool {
+	for _, kv := range strings.Split(tag, ",") {
+		k, v, found := strings.Cut(kv, "=")
+		if !found {
+			continue
+		}
+
+		switch k {
+		case "format":
+			s.Format = v
+		case "description":
+			s.Desc = v
+		case "example":
+			s.Example = v
+		case "default":
+			s.Default = v
+		case "pattern":
+			s.Pattern = v
+		case "minimum":
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				s.Minimum = &f
+			}
+		case "maximum":
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				s.Maximum = &f
+			}
+		case "enum":
+			for _, e := range strings.Split(v, "|") {
+				s.Enum = append(s.Enum, e)
+			}
+		case "required":
+			required = v != "false"
+		}
+	}
+
+	return required
+}
@@ -0,0 +1,139 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/zJeremiah/go-openapi/spec/internal/otheritem"
+)
+
+type reflectAddress struct {
+	City string `json:"city"`
+}
+
+// Item collides, by bare name, with otheritem.Item - used to verify
+// buildNamed disambiguates same-named types from different packages
+// instead of letting one overwrite the other's component.
+type Item struct {
+	Name string `json:"name"`
+}
+
+type reflectUser struct {
+	ID      string         `json:"id" openapi:"format=uuid"`
+	Name    string         `json:"name,omitempty"`
+	Age     int            `json:"age" openapi:"minimum=0,maximum=130"`
+	Role    string         `json:"role" openapi:"enum=admin|member"`
+	Address reflectAddress `json:"address"`
+	Friend  *reflectUser   `json:"friend,omitempty"`
+}
+
+func TestSchemaForLiftsNamedTypeToComponents(t *testing.T) {
+	o := New("t", "1.0", "")
+
+	s := o.SchemaFor(reflectUser{})
+
+	if s.Ref != "#/components/schemas/reflectUser" {
+		t.Fatalf("expected a $ref to the lifted type, got %+v", s)
+	}
+
+	lifted, ok := o.Components.Schemas["reflectUser"]
+	if !ok {
+		t.Fatalf("expected reflectUser to be lifted into components/schemas")
+	}
+
+	id := lifted.Properties["id"]
+	if id == nil || id.Format != "uuid" {
+		t.Fatalf("expected id to carry format=uuid from its openapi tag, got %+v", id)
+	}
+
+	age := lifted.Properties["age"]
+	if age == nil || age.Minimum == nil || *age.Minimum != 0 || age.Maximum == nil || *age.Maximum != 130 {
+		t.Fatalf("expected age to carry minimum/maximum from its openapi tag, got %+v", age)
+	}
+
+	role := lifted.Properties["role"]
+	if role == nil || len(role.Enum) != 2 {
+		t.Fatalf("expected role to carry a two-value enum, got %+v", role)
+	}
+
+	var required []string
+	required = append(required, lifted.Required...)
+	if !contains(required, "id") || contains(required, "name") {
+		t.Fatalf("expected id required and name (omitempty) not required, got %v", required)
+	}
+}
+
+func TestSchemaForDedupesRepeatedNamedType(t *testing.T) {
+	o := New("t", "1.0", "")
+
+	type pair struct {
+		A reflectAddress `json:"a"`
+		B reflectAddress `json:"b"`
+	}
+
+	s := o.SchemaFor(pair{})
+
+	lifted := o.Components.Schemas["pair"]
+	if lifted == nil {
+		t.Fatalf("expected pair to be lifted into components/schemas, got ref %q", s.Ref)
+	}
+	a := lifted.Properties["a"]
+	b := lifted.Properties["b"]
+	if a == nil || b == nil || a.Ref == "" || a.Ref != b.Ref {
+		t.Fatalf("expected both fields to $ref the same lifted reflectAddress schema, got a=%+v b=%+v", a, b)
+	}
+	if _, ok := o.Components.Schemas["reflectAddress"]; !ok {
+		t.Fatalf("expected reflectAddress to be lifted into components/schemas")
+	}
+}
+
+func TestSchemaForHandlesCycles(t *testing.T) {
+	o := New("t", "1.0", "")
+
+	s := o.SchemaFor(reflectUser{})
+	if s.Ref == "" {
+		t.Fatalf("expected a $ref for the cyclic type, got %+v", s)
+	}
+
+	friend := o.Components.Schemas["reflectUser"].Properties["friend"]
+	if friend == nil || friend.Ref != "#/components/schemas/reflectUser" {
+		t.Fatalf("expected friend to be a self-$ref rather than recursing forever, got %+v", friend)
+	}
+}
+
+func TestSchemaForDisambiguatesSameNamedTypeFromDifferentPackages(t *testing.T) {
+	o := New("t", "1.0", "")
+
+	type holder struct {
+		A Item           `json:"a"`
+		B otheritem.Item `json:"b"`
+	}
+
+	o.SchemaFor(holder{})
+
+	a := o.Components.Schemas["holder"].Properties["a"]
+	b := o.Components.Schemas["holder"].Properties["b"]
+	if a == nil || b == nil || a.Ref == b.Ref {
+		t.Fatalf("expected the two Item types to $ref distinct components, got a=%+v b=%+v", a, b)
+	}
+
+	nameField, ok := o.Components.Schemas["Item"].Properties["name"]
+	if !ok {
+		t.Fatalf("expected the local Item's name field to be preserved, got %+v", o.Components.Schemas["Item"])
+	}
+	_ = nameField
+
+	labelField, ok := o.Components.Schemas["Item2"].Properties["label"]
+	if !ok {
+		t.Fatalf("expected otheritem.Item to be lifted under a disambiguated name with its own label field, got %+v", o.Components.Schemas["Item2"])
+	}
+	_ = labelField
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
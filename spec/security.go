@@ -0,0 +1,96 @@
+package spec
+
+import "fmt"
+
+// AddAPIKeyAuth registers a securityScheme that authenticates via an API
+// key sent in the given location ("query", "header" or "cookie") under
+// headerName.
+func (o *OpenAPI) AddAPIKeyAuth(name, in, headerName string) {
+	o.addSecurityScheme(name, &SecurityScheme{
+		Type: "apiKey",
+		Name: headerName,
+		In:   in,
+	})
+}
+
+// AddHTTPBearerAuth registers a securityScheme that authenticates via an
+// "Authorization: Bearer <token>" header. bearerFormat is a hint for
+// documentation purposes only (e.g. "JWT") and may be left empty.
+func (o *OpenAPI) AddHTTPBearerAuth(name, bearerFormat string) {
+	o.addSecurityScheme(name, &SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: bearerFormat,
+	})
+}
+
+// AddBasicAuth registers a securityScheme that authenticates via HTTP
+// basic auth.
+func (o *OpenAPI) AddBasicAuth(name string) {
+	o.addSecurityScheme(name, &SecurityScheme{
+		Type:   "http",
+		Scheme: "basic",
+	})
+}
+
+// AddOAuth2Auth registers a securityScheme that authenticates via OAuth2,
+// with flows describing which of the implicit, password, clientCredentials
+// and authorizationCode grants are supported.
+func (o *OpenAPI) AddOAuth2Auth(name string, flows OAuth2Flows) {
+	o.addSecurityScheme(name, &SecurityScheme{
+		Type:  "oauth2",
+		Flows: &flows,
+	})
+}
+
+// AddOpenIDConnectAuth registers a securityScheme that authenticates via
+// OpenID Connect discovery, resolving further detail from url.
+func (o *OpenAPI) AddOpenIDConnectAuth(name, url string) {
+	o.addSecurityScheme(name, &SecurityScheme{
+		Type:             "openIdConnect",
+		OpenIDConnectURL: url,
+	})
+}
+
+func (o *OpenAPI) addSecurityScheme(name string, s *SecurityScheme) {
+	if o.Components.SecuritySchemes == nil {
+		o.Components.SecuritySchemes = map[string]*SecurityScheme{}
+	}
+	o.Components.SecuritySchemes[name] = s
+}
+
+// RequireAuth attaches a security requirement to ur's operation, naming
+// the securityScheme (added via one of the AddXAuth constructors) and, for
+// oauth2/openIdConnect schemes, the scopes required. Calling RequireAuth
+// more than once for the same route adds an alternative requirement
+// ("OR" semantics) - RequireAuth only ever names one scheme per call, so
+// requiring several schemes at once ("AND" semantics) means appending a
+// SecurityRequirement naming all of them directly to the operation's
+// Security field in o.Paths instead.
+func (o *OpenAPI) RequireAuth(ur UniqueRoute, schemeName string, scopes ...string) error {
+	p, found := o.Paths[ur.Path]
+	if !found {
+		return fmt.Errorf("could not find path to add security %v", ur)
+	}
+	m, found := p[ur.Method]
+	if !found {
+		return fmt.Errorf("could not find method to add security %v", ur)
+	}
+
+	if scopes == nil {
+		scopes = []string{}
+	}
+	m.Security = append(m.Security, SecurityRequirement{schemeName: scopes})
+
+	p[ur.Method] = m
+	o.Paths[ur.Path] = p
+
+	return nil
+}
+
+// SetGlobalSecurity sets the document-level default security requirement,
+// applied to every operation that does not declare its own Security via
+// RequireAuth. Passing no requirements clears it.
+func (o *OpenAPI) SetGlobalSecurity(reqs ...SecurityRequirement) {
+	o.Security = reqs
+}
@@ -0,0 +1,93 @@
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRequireAuthAppendsAlternativeRequirements(t *testing.T) {
+	o := New("t", "1.0", "")
+	o.AddAPIKeyAuth("apiKey", "header", "X-API-Key")
+	o.AddHTTPBearerAuth("bearer", "JWT")
+
+	ur, err := o.AddRoute("/widgets", "get", "widgets", "", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if err := o.RequireAuth(ur, "apiKey"); err != nil {
+		t.Fatalf("RequireAuth: %v", err)
+	}
+	if err := o.RequireAuth(ur, "bearer"); err != nil {
+		t.Fatalf("RequireAuth: %v", err)
+	}
+
+	op := o.Paths["/widgets"]["get"]
+	if len(op.Security) != 2 {
+		t.Fatalf("expected two alternative security requirements, got %+v", op.Security)
+	}
+	if _, ok := op.Security[0]["apiKey"]; !ok {
+		t.Fatalf("expected the first requirement to name apiKey, got %+v", op.Security[0])
+	}
+	if _, ok := op.Security[1]["bearer"]; !ok {
+		t.Fatalf("expected the second requirement to name bearer, got %+v", op.Security[1])
+	}
+}
+
+func TestRequireAuthCarriesOAuth2Scopes(t *testing.T) {
+	o := New("t", "1.0", "")
+	o.AddOAuth2Auth("oauth2", OAuth2Flows{
+		ClientCredentials: &OAuth2Flow{TokenURL: "https://example.com/token", Scopes: map[string]string{"read": "read access"}},
+	})
+
+	ur, err := o.AddRoute("/widgets", "get", "widgets", "", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if err := o.RequireAuth(ur, "oauth2", "read"); err != nil {
+		t.Fatalf("RequireAuth: %v", err)
+	}
+
+	op := o.Paths["/widgets"]["get"]
+	if len(op.Security) != 1 || len(op.Security[0]["oauth2"]) != 1 || op.Security[0]["oauth2"][0] != "read" {
+		t.Fatalf("expected the oauth2 requirement to carry the read scope, got %+v", op.Security)
+	}
+}
+
+func TestRequireAuthRejectsUnknownRoute(t *testing.T) {
+	o := New("t", "1.0", "")
+	if err := o.RequireAuth(UniqueRoute{Path: "/missing", Method: "get"}, "apiKey"); err == nil {
+		t.Fatalf("expected an error requiring auth on a route that was never added")
+	}
+}
+
+func TestSetGlobalSecurityRendersAsDocumentDefault(t *testing.T) {
+	o := New("t", "1.0", "")
+	o.AddAPIKeyAuth("apiKey", "header", "X-API-Key")
+	o.SetGlobalSecurity(SecurityRequirement{"apiKey": {}})
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(o.JSON()), &doc); err != nil {
+		t.Fatalf("unmarshaling rendered doc: %v", err)
+	}
+
+	security, ok := doc["security"].([]any)
+	if !ok || len(security) != 1 {
+		t.Fatalf("expected a single default security requirement, got %+v", doc["security"])
+	}
+
+	schemes := doc["components"].(map[string]any)["securitySchemes"].(map[string]any)
+	apiKey := schemes["apiKey"].(map[string]any)
+	if apiKey["type"] != "apiKey" || apiKey["name"] != "X-API-Key" || apiKey["in"] != "header" {
+		t.Fatalf("expected apiKey securityScheme fields to render, got %+v", apiKey)
+	}
+}
+
+func TestSetGlobalSecurityClearsWithNoArgs(t *testing.T) {
+	o := New("t", "1.0", "")
+	o.SetGlobalSecurity(SecurityRequirement{"apiKey": {}})
+	o.SetGlobalSecurity()
+
+	if len(o.Security) != 0 {
+		t.Fatalf("expected SetGlobalSecurity() with no args to clear security, got %+v", o.Security)
+	}
+}
@@ -0,0 +1,220 @@
+package spec
+
+import "reflect"
+
+// Info provides metadata about the API.
+type Info struct {
+	Title          string   `json:"title"`
+	Desc           string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
+	Version        string   `json:"version"`
+}
+
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// ExternalDocs points to additional external documentation for the API
+// or for a single tag.
+type ExternalDocs struct {
+	Desc string `json:"description,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Tag is used to group operations together in generated documentation.
+type Tag struct {
+	Name         string        `json:"name"`
+	Desc         string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+}
+
+// Paths maps a URL path to the operations available on it.
+type Paths map[string]OperationMap
+
+// OperationMap maps an HTTP method to its operation definition for a path.
+type OperationMap map[Method]Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Desc        string                `json:"description,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Params      []Param               `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   Responses             `json:"responses,omitempty"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Param describes a single operation parameter, rendered under the
+// "parameters" array of an operation.
+type Param struct {
+	Name     string         `json:"name"`
+	Desc     string         `json:"description,omitempty"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *Schema        `json:"schema,omitempty"`
+	Example  map[string]any `json:"example,omitempty"`
+}
+
+// RequestBody describes a request body accepted by an operation.
+type RequestBody struct {
+	Desc     string           `json:"description,omitempty"`
+	Required bool             `json:"required,omitempty"`
+	Content  map[string]Media `json:"content,omitempty"`
+}
+
+// Responses maps an HTTP status code (or "default") to its response.
+type Responses map[string]Response
+
+// Response describes a single response from an API operation.
+type Response struct {
+	Desc    string           `json:"description"`
+	Content map[string]Media `json:"content,omitempty"`
+}
+
+// Media describes the schema for a single media type entry, e.g. the value
+// at content["application/json"].
+type Media struct {
+	Schema Schema `json:"schema"`
+}
+
+// Components holds reusable objects referenced from elsewhere in the
+// document via $ref.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes a single authentication mechanism available to
+// operations, built via AddAPIKeyAuth, AddHTTPBearerAuth, AddBasicAuth,
+// AddOAuth2Auth or AddOpenIDConnectAuth and referenced by name from
+// RequireAuth, SetGlobalSecurity and SecurityRequirement.
+type SecurityScheme struct {
+	Type             string       `json:"type"`
+	Desc             string       `json:"description,omitempty"`
+	Name             string       `json:"name,omitempty"`             // apiKey
+	In               string       `json:"in,omitempty"`               // apiKey: "query", "header" or "cookie"
+	Scheme           string       `json:"scheme,omitempty"`           // http: "bearer" or "basic"
+	BearerFormat     string       `json:"bearerFormat,omitempty"`     // http bearer
+	Flows            *OAuth2Flows `json:"flows,omitempty"`            // oauth2
+	OpenIDConnectURL string       `json:"openIdConnectUrl,omitempty"` // openIdConnect
+}
+
+// OAuth2Flows holds the OAuth2 flow configurations a securityScheme
+// supports; only the fields corresponding to flows actually offered
+// should be set.
+type OAuth2Flows struct {
+	Implicit          *OAuth2Flow `json:"implicit,omitempty"`
+	Password          *OAuth2Flow `json:"password,omitempty"`
+	ClientCredentials *OAuth2Flow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuth2Flow `json:"authorizationCode,omitempty"`
+}
+
+// OAuth2Flow configures a single OAuth2 flow. AuthorizationURL is required
+// for implicit and authorizationCode; TokenURL is required for password,
+// clientCredentials and authorizationCode; RefreshURL is optional for all.
+type OAuth2Flow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// SecurityRequirement names a securityScheme and, for oauth2/openIdConnect
+// schemes, the scopes required; an empty slice means no scopes (or any
+// scheme that isn't oauth2/openIdConnect). A document-level or
+// operation-level security value is a list of these, each alternative
+// satisfying the requirement on its own ("OR" semantics between entries,
+// "AND" between the schemes named in a single entry).
+type SecurityRequirement map[string][]string
+
+// Schema represents an OpenAPI Schema Object describing the shape of a
+// value: its type, format, nested items/properties, and validation
+// constraints. It is built by reflection via OpenAPI.SchemaFor and
+// Properties.Construct.
+type Schema struct {
+	Ref                  string     `json:"$ref,omitempty"`
+	Type                 string     `json:"type,omitempty"`
+	Format               string     `json:"format,omitempty"`
+	Title                string     `json:"title,omitempty"`
+	Desc                 string     `json:"description,omitempty"`
+	Items                *Schema    `json:"items,omitempty"`
+	Properties           Properties `json:"properties,omitempty"`
+	AdditionalProperties *Schema    `json:"additionalProperties,omitempty"`
+	Required             []string   `json:"required,omitempty"`
+	Enum                 []any      `json:"enum,omitempty"`
+	Default              any        `json:"default,omitempty"`
+	Example              any        `json:"example,omitempty"`
+	Nullable             bool       `json:"nullable,omitempty"`
+	ReadOnly             bool       `json:"readOnly,omitempty"`
+	WriteOnly            bool       `json:"writeOnly,omitempty"`
+	Minimum              *float64   `json:"minimum,omitempty"`
+	Maximum              *float64   `json:"maximum,omitempty"`
+	MinLength            *int       `json:"minLength,omitempty"`
+	MaxLength            *int       `json:"maxLength,omitempty"`
+	Pattern              string     `json:"pattern,omitempty"`
+
+	// The remaining fields only apply to OpenAPI 3.1 / JSON Schema 2020-12
+	// documents; see New31 and OpenAPI.JSON. TypeUnion, when non-empty,
+	// takes precedence over Type in MarshalJSON (schemaFor31 uses it to
+	// render a 3.1 ["<type>","null"] union in place of 3.0's nullable:true).
+	TypeUnion             []string  `json:"-"`
+	Examples              []any     `json:"examples,omitempty"`
+	ExclusiveMinimum      *float64  `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum      *float64  `json:"exclusiveMaximum,omitempty"`
+	Dialect               string    `json:"$schema,omitempty"`
+	ContentEncoding       string    `json:"contentEncoding,omitempty"`
+	ContentMediaType      string    `json:"contentMediaType,omitempty"`
+	If                    *Schema   `json:"if,omitempty"`
+	Then                  *Schema   `json:"then,omitempty"`
+	Else                  *Schema   `json:"else,omitempty"`
+	PrefixItems           []*Schema `json:"prefixItems,omitempty"`
+	UnevaluatedProperties *Schema   `json:"unevaluatedProperties,omitempty"`
+
+	// exclusiveMinBool/exclusiveMaxBool are set by render30 (see v30.go)
+	// when rewriting ExclusiveMinimum/ExclusiveMaximum for 3.0.3 output,
+	// where they are Draft-4 style booleans paired with Minimum/Maximum
+	// rather than 3.1's bare numbers; MarshalJSON consults them to choose
+	// which shape to emit.
+	exclusiveMinBool bool
+	exclusiveMaxBool bool
+}
+
+// Properties is a named set of schemas, keyed by field/property name.
+type Properties map[string]*Schema
+
+// Webhooks maps a webhook name to the path item describing the requests an
+// API consumer should expect to receive for it. Only rendered for OpenAPI
+// 3.1 documents; see New31.
+type Webhooks map[string]OperationMap
+
+// OpenAPI is the root document object of an OpenAPI spec, built up
+// incrementally via AddRoute, AddParam, AddResp and friends, then rendered
+// with JSON.
+type OpenAPI struct {
+	Version           string                `json:"openapi"`
+	JSONSchemaDialect string                `json:"jsonSchemaDialect,omitempty"` // 3.1 only; see New31
+	Info              Info                  `json:"info"`
+	Tags              []Tag                 `json:"tags,omitempty"`
+	Paths             Paths                 `json:"paths"`
+	Webhooks          Webhooks              `json:"webhooks,omitempty"` // 3.1 only; see New31
+	Components        Components            `json:"components,omitempty"`
+	Security          []SecurityRequirement `json:"security,omitempty"` // see SetGlobalSecurity
+	ExternalDocs      *ExternalDocs         `json:"externalDocs,omitempty"`
+	Routes            map[UniqueRoute]Route `json:"-"` // internal bookkeeping, not part of the rendered spec
+
+	// schemaTypes records, across every SchemaFor call, which
+	// components/schemas name was assigned to a given reflect.Type; see
+	// schemaRegistry.buildNamed in reflect.go.
+	schemaTypes map[reflect.Type]string
+}
@@ -0,0 +1,132 @@
+package spec
+
+// render30 returns a copy of o with every schema reachable from Paths and
+// Components.Schemas rewritten for OpenAPI 3.0.3 output, and with the
+// 3.1-only Webhooks and JSONSchemaDialect fields dropped. o itself is left
+// untouched so callers can keep building on it after calling JSON.
+func (o *OpenAPI) render30() *OpenAPI {
+	out := *o
+	out.Webhooks = nil
+	out.JSONSchemaDialect = ""
+
+	out.Paths = render30Paths(o.Paths)
+
+	if o.Components.Schemas != nil {
+		schemas := make(map[string]*Schema, len(o.Components.Schemas))
+		for name, s := range o.Components.Schemas {
+			schemas[name] = schemaFor30(s)
+		}
+		out.Components.Schemas = schemas
+	}
+
+	return &out
+}
+
+func render30Paths(paths Paths) Paths {
+	if paths == nil {
+		return nil
+	}
+
+	out := make(Paths, len(paths))
+	for path, ops := range paths {
+		newOps := make(OperationMap, len(ops))
+		for method, op := range ops {
+			newOps[method] = op.render30()
+		}
+		out[path] = newOps
+	}
+
+	return out
+}
+
+// render30 returns a copy of op with every parameter, request body and
+// response schema rewritten for 3.0.3 output.
+func (op Operation) render30() Operation {
+	out := op
+
+	if len(op.Params) > 0 {
+		params := make([]Param, len(op.Params))
+		copy(params, op.Params)
+		for i, p := range params {
+			params[i].Schema = schemaFor30(p.Schema)
+		}
+		out.Params = params
+	}
+
+	if op.RequestBody != nil {
+		rb := *op.RequestBody
+		rb.Content = render30Content(rb.Content)
+		out.RequestBody = &rb
+	}
+
+	if op.Responses != nil {
+		responses := make(Responses, len(op.Responses))
+		for code, resp := range op.Responses {
+			resp.Content = render30Content(resp.Content)
+			responses[code] = resp
+		}
+		out.Responses = responses
+	}
+
+	return out
+}
+
+func render30Content(content map[string]Media) map[string]Media {
+	if content == nil {
+		return nil
+	}
+
+	out := make(map[string]Media, len(content))
+	for mime, media := range content {
+		out[mime] = Media{Schema: *schemaFor30(&media.Schema)}
+	}
+
+	return out
+}
+
+// schemaFor30 returns a copy of s rewritten for OpenAPI 3.0.3 output:
+// ExclusiveMinimum/ExclusiveMaximum, which 3.1 represents as a bare
+// number, are 3.0's Draft-4 style booleans that modify minimum/maximum
+// instead - "exclusiveMinimum: true" only means something alongside a
+// "minimum" value, so when Minimum/Maximum isn't already set, the
+// threshold is moved there and exclusiveMinBool/exclusiveMaxBool (see
+// Schema.MarshalJSON) record that it should render as true rather than
+// as a number.
+//
+// the walk recurses into items, properties and additionalProperties so
+// the whole tree picks up the same treatment.
+func schemaFor30(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+
+	if out.ExclusiveMinimum != nil {
+		if out.Minimum == nil {
+			out.Minimum = out.ExclusiveMinimum
+		}
+		out.ExclusiveMinimum = nil
+		out.exclusiveMinBool = true
+	}
+	if out.ExclusiveMaximum != nil {
+		if out.Maximum == nil {
+			out.Maximum = out.ExclusiveMaximum
+		}
+		out.ExclusiveMaximum = nil
+		out.exclusiveMaxBool = true
+	}
+
+	out.Items = schemaFor30(out.Items)
+	out.AdditionalProperties = schemaFor30(out.AdditionalProperties)
+
+	if out.Properties != nil {
+		props := make(Properties, len(out.Properties))
+		for name, p := range out.Properties {
+			props[name] = schemaFor30(p)
+		}
+		out.Properties = props
+	}
+
+	return &out
+}
@@ -0,0 +1,48 @@
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNewRendersExclusiveMinimumAsBoolean(t *testing.T) {
+	min := 5.0
+	o := New("t", "1.0", "")
+	o.Components.Schemas = map[string]*Schema{
+		"Bounded": {Type: Number.String(), ExclusiveMinimum: &min},
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(o.JSON()), &doc); err != nil {
+		t.Fatalf("unmarshaling rendered doc: %v", err)
+	}
+
+	schema := doc["components"].(map[string]any)["schemas"].(map[string]any)["Bounded"].(map[string]any)
+	if schema["exclusiveMinimum"] != true {
+		t.Fatalf("expected 3.0.3 output to render exclusiveMinimum as true, got %+v", schema["exclusiveMinimum"])
+	}
+	if schema["minimum"] != min {
+		t.Fatalf("expected the threshold to be carried on minimum, got %+v", schema["minimum"])
+	}
+}
+
+func TestNew31RendersExclusiveMinimumAsNumber(t *testing.T) {
+	min := 5.0
+	o := New31("t", "1.0", "")
+	o.Components.Schemas = map[string]*Schema{
+		"Bounded": {Type: Number.String(), ExclusiveMinimum: &min},
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(o.JSON()), &doc); err != nil {
+		t.Fatalf("unmarshaling rendered doc: %v", err)
+	}
+
+	schema := doc["components"].(map[string]any)["schemas"].(map[string]any)["Bounded"].(map[string]any)
+	if schema["exclusiveMinimum"] != min {
+		t.Fatalf("expected 3.1 output to keep exclusiveMinimum as a number, got %+v", schema["exclusiveMinimum"])
+	}
+	if _, hasMinimum := schema["minimum"]; hasMinimum {
+		t.Fatalf("expected no minimum to be introduced for 3.1 output, got %+v", schema)
+	}
+}
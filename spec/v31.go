@@ -0,0 +1,226 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// is31 reports whether version targets OpenAPI 3.1 / JSON Schema 2020-12
+// output rather than 3.0.x.
+func is31(version string) bool {
+	return strings.HasPrefix(version, "3.1")
+}
+
+// render31 returns a copy of o with every schema reachable from Paths,
+// Webhooks and Components.Schemas rewritten for 3.1 output. o itself is
+// left untouched so callers can keep building on it after calling JSON.
+func (o *OpenAPI) render31() *OpenAPI {
+	out := *o
+
+	out.Paths = render31Paths(o.Paths)
+	out.Webhooks = Webhooks(render31Paths(Paths(o.Webhooks)))
+
+	if o.Components.Schemas != nil {
+		schemas := make(map[string]*Schema, len(o.Components.Schemas))
+		for name, s := range o.Components.Schemas {
+			schemas[name] = schemaFor31(s)
+		}
+		out.Components.Schemas = schemas
+	}
+
+	return &out
+}
+
+func render31Paths(paths Paths) Paths {
+	if paths == nil {
+		return nil
+	}
+
+	out := make(Paths, len(paths))
+	for path, ops := range paths {
+		newOps := make(OperationMap, len(ops))
+		for method, op := range ops {
+			newOps[method] = op.render31()
+		}
+		out[path] = newOps
+	}
+
+	return out
+}
+
+// render31 returns a copy of op with every parameter, request body and
+// response schema rewritten for 3.1 output.
+func (op Operation) render31() Operation {
+	out := op
+
+	if len(op.Params) > 0 {
+		params := make([]Param, len(op.Params))
+		copy(params, op.Params)
+		for i, p := range params {
+			params[i].Schema = schemaFor31(p.Schema)
+		}
+		out.Params = params
+	}
+
+	if op.RequestBody != nil {
+		rb := *op.RequestBody
+		rb.Content = render31Content(rb.Content)
+		out.RequestBody = &rb
+	}
+
+	if op.Responses != nil {
+		responses := make(Responses, len(op.Responses))
+		for code, resp := range op.Responses {
+			resp.Content = render31Content(resp.Content)
+			responses[code] = resp
+		}
+		out.Responses = responses
+	}
+
+	return out
+}
+
+func render31Content(content map[string]Media) map[string]Media {
+	if content == nil {
+		return nil
+	}
+
+	out := make(map[string]Media, len(content))
+	for mime, media := range content {
+		out[mime] = Media{Schema: *schemaFor31(&media.Schema)}
+	}
+
+	return out
+}
+
+// schemaFor31 returns a copy of s rewritten for OpenAPI 3.1 / JSON Schema
+// 2020-12 output:
+//   - nullable:true becomes a type union ["<type>","null"], carried in
+//     TypeUnion since Type is a single string (see Schema.MarshalJSON)
+//   - a single Example is moved into the Examples array
+//
+// the walk recurses into every nested schema (items, properties,
+// additionalProperties, prefixItems, if/then/else, unevaluatedProperties)
+// so the whole tree picks up the same treatment.
+func schemaFor31(s *Schema) *Schema {
+	if s == nil {
+		return nil
+	}
+
+	out := *s
+
+	if out.Nullable {
+		out.Nullable = false
+		if out.Type != "" {
+			out.TypeUnion = []string{out.Type, "null"}
+			out.Type = ""
+		}
+	}
+
+	if out.Example != nil && len(out.Examples) == 0 {
+		out.Examples = []any{out.Example}
+		out.Example = nil
+	}
+
+	out.Items = schemaFor31(out.Items)
+	out.AdditionalProperties = schemaFor31(out.AdditionalProperties)
+	out.If = schemaFor31(out.If)
+	out.Then = schemaFor31(out.Then)
+	out.Else = schemaFor31(out.Else)
+	out.UnevaluatedProperties = schemaFor31(out.UnevaluatedProperties)
+
+	if out.Properties != nil {
+		props := make(Properties, len(out.Properties))
+		for name, p := range out.Properties {
+			props[name] = schemaFor31(p)
+		}
+		out.Properties = props
+	}
+
+	if out.PrefixItems != nil {
+		items := make([]*Schema, len(out.PrefixItems))
+		for i, p := range out.PrefixItems {
+			items[i] = schemaFor31(p)
+		}
+		out.PrefixItems = items
+	}
+
+	return &out
+}
+
+// MarshalJSON renders the schema's "type" keyword: TypeUnion, when set by
+// schemaFor31 for a 3.1 nullable union, takes precedence over the plain
+// Type string. It also renders exclusiveMinimum/exclusiveMaximum as the
+// Draft-4 style boolean render30 converted them to for 3.0.3 output, when
+// exclusiveMinBool/exclusiveMaxBool are set, instead of the plain number
+// used everywhere else (3.1 output, and Schema values built but never
+// passed through render30).
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+
+	out := struct {
+		alias
+		Type             any `json:"type,omitempty"`
+		ExclusiveMinimum any `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum any `json:"exclusiveMaximum,omitempty"`
+	}{alias: alias(s)}
+
+	if len(s.TypeUnion) > 0 {
+		out.Type = s.TypeUnion
+	} else if s.Type != "" {
+		out.Type = s.Type
+	}
+
+	switch {
+	case s.exclusiveMinBool:
+		out.ExclusiveMinimum = true
+	case s.ExclusiveMinimum != nil:
+		out.ExclusiveMinimum = *s.ExclusiveMinimum
+	}
+	switch {
+	case s.exclusiveMaxBool:
+		out.ExclusiveMaximum = true
+	case s.ExclusiveMaximum != nil:
+		out.ExclusiveMaximum = *s.ExclusiveMaximum
+	}
+
+	return jsoniter.ConfigFastest.Marshal(out)
+}
+
+// UnmarshalJSON is the counterpart to MarshalJSON: it accepts "type" as
+// either a plain string (3.0) or an array of strings (3.1's nullable
+// union), populating Type or TypeUnion accordingly. This lets Load ingest
+// documents written for either version.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type alias Schema
+
+	aux := struct {
+		*alias
+		Type json.RawMessage `json:"type,omitempty"`
+	}{alias: (*alias)(s)}
+
+	if err := jsoniter.ConfigFastest.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	if len(aux.Type) == 0 {
+		return nil
+	}
+
+	var single string
+	if err := jsoniter.ConfigFastest.Unmarshal(aux.Type, &single); err == nil {
+		s.Type = single
+		return nil
+	}
+
+	var union []string
+	if err := jsoniter.ConfigFastest.Unmarshal(aux.Type, &union); err != nil {
+		return fmt.Errorf("schema: \"type\" must be a string or array of strings: %w", err)
+	}
+	s.TypeUnion = union
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package spec
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNew31RendersNullableAsTypeUnion(t *testing.T) {
+	o := New31("t", "1.0", "")
+	o.Components.Schemas = map[string]*Schema{
+		"Nullable": {Type: String.String(), Nullable: true},
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(o.JSON()), &doc); err != nil {
+		t.Fatalf("unmarshaling rendered doc: %v", err)
+	}
+
+	schema := doc["components"].(map[string]any)["schemas"].(map[string]any)["Nullable"].(map[string]any)
+	if _, hasNullable := schema["nullable"]; hasNullable {
+		t.Fatalf("expected 3.1 output to drop nullable, got %+v", schema)
+	}
+
+	typ, ok := schema["type"].([]any)
+	if !ok || len(typ) != 2 || typ[0] != "string" || typ[1] != "null" {
+		t.Fatalf(`expected type union ["string","null"], got %+v`, schema["type"])
+	}
+}
+
+func TestNew31RendersExampleAsExamplesArray(t *testing.T) {
+	o := New31("t", "1.0", "")
+	o.Components.Schemas = map[string]*Schema{
+		"Named": {Type: String.String(), Example: "foo"},
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(o.JSON()), &doc); err != nil {
+		t.Fatalf("unmarshaling rendered doc: %v", err)
+	}
+
+	schema := doc["components"].(map[string]any)["schemas"].(map[string]any)["Named"].(map[string]any)
+	if _, hasExample := schema["example"]; hasExample {
+		t.Fatalf("expected 3.1 output to drop example, got %+v", schema)
+	}
+	if examples, ok := schema["examples"].([]any); !ok || len(examples) != 1 || examples[0] != "foo" {
+		t.Fatalf("expected examples: [\"foo\"], got %+v", schema["examples"])
+	}
+}
+
+func TestNewDefaultsTo303(t *testing.T) {
+	o := New("t", "1.0", "")
+	if o.Version != Version30 {
+		t.Fatalf("expected New to default to %s, got %s", Version30, o.Version)
+	}
+}
@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+// Middleware returns an http.Handler that validates every request against
+// v's spec before calling next, and validates next's response before it
+// reaches the client. A request or response that fails validation never
+// reaches next/the client; its errors are written as the body of a
+// 400 (request) or 500 (response) instead. Use ValidateRequest and
+// ValidateResponse directly for finer control.
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ur, err := v.ValidateRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+
+		if err := v.ValidateResponse(ur, rec.Result()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		for k, vals := range rec.Header() {
+			for _, val := range vals {
+				w.Header().Add(k, val)
+			}
+		}
+		w.WriteHeader(rec.Code)
+		_, _ = io.Copy(w, bytes.NewReader(rec.Body.Bytes()))
+	})
+}
+
+// ValidateResponse validates resp against the operation matched by ur,
+// checking that its status code and content type are declared and, if so,
+// that its body matches the declared schema. resp.Body is left readable
+// afterwards.
+func (v *Validator) ValidateResponse(ur spec.UniqueRoute, resp *http.Response) error {
+	methods, ok := v.api.Paths[ur.Path]
+	if !ok {
+		return fmt.Errorf("validate: unknown path %q", ur.Path)
+	}
+	op, ok := methods[ur.Method]
+	if !ok {
+		return fmt.Errorf("validate: unknown method %q for %q", ur.Method, ur.Path)
+	}
+
+	def, ok := op.Responses[strconv.Itoa(resp.StatusCode)]
+	if !ok {
+		def, ok = op.Responses[spec.Default]
+	}
+	if !ok {
+		return fmt.Errorf("validate: status %d is not declared for %s %s", resp.StatusCode, ur.Method, ur.Path)
+	}
+	if len(def.Content) == 0 {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if len(body) == 0 {
+		return nil
+	}
+
+	if errs := v.validateBody(def.Content, resp.Header.Get("Content-Type"), body, directionResponse); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
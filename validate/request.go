@@ -0,0 +1,88 @@
+package validate
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+// ValidateRequest matches r against the spec and validates its path, query,
+// header and cookie parameters plus its body (if the matched operation
+// declares one), returning the matched route. A non-nil error is always an
+// Errors aggregating every violation found, except when no route matches
+// at all.
+func (v *Validator) ValidateRequest(r *http.Request) (spec.UniqueRoute, error) {
+	ur, op, pathParams, ok := v.Match(r.Method, r.URL.Path)
+	if !ok {
+		return ur, fmt.Errorf("validate: no route matches %s %s", r.Method, r.URL.Path)
+	}
+
+	var errs Errors
+
+	for _, p := range op.Params {
+		val, present := paramValue(r, p, pathParams)
+
+		if p.Required && !present {
+			errs = append(errs, fmt.Errorf("%s parameter %q is required", p.In, p.Name))
+			continue
+		}
+		if present && p.Schema != nil {
+			errs = append(errs, v.validateValue(p.Schema, val, directionRequest, p.Name)...)
+		}
+	}
+
+	if op.RequestBody != nil {
+		errs = append(errs, v.validateRequestBody(r, op)...)
+	}
+
+	if len(errs) > 0 {
+		return ur, errs
+	}
+	return ur, nil
+}
+
+func (v *Validator) validateRequestBody(r *http.Request, op spec.Operation) Errors {
+	if r.Body == nil || r.Body == http.NoBody {
+		if op.RequestBody.Required {
+			return Errors{fmt.Errorf("request body is required")}
+		}
+		return nil
+	}
+
+	body, err := readAndRestore(&r.Body)
+	if err != nil {
+		return Errors{fmt.Errorf("reading request body: %w", err)}
+	}
+	if len(body) == 0 {
+		if op.RequestBody.Required {
+			return Errors{fmt.Errorf("request body is required")}
+		}
+		return nil
+	}
+
+	return v.validateBody(op.RequestBody.Content, r.Header.Get("Content-Type"), body, directionRequest)
+}
+
+// paramValue reads a declared parameter's raw value off the request,
+// returning ok=false when it was not supplied at all.
+func paramValue(r *http.Request, p spec.Param, pathParams map[string]string) (string, bool) {
+	switch p.In {
+	case "path":
+		val, ok := pathParams[p.Name]
+		return val, ok
+	case "query":
+		return firstQuery(r.URL.Query(), p.Name)
+	case "header":
+		val := r.Header.Get(p.Name)
+		return val, val != ""
+	case "cookie":
+		c, err := r.Cookie(p.Name)
+		if err != nil {
+			return "", false
+		}
+		return c.Value, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+func newUUIDParamAPI(t *testing.T) *spec.OpenAPI {
+	t.Helper()
+
+	api := spec.New("t", "1.0", "")
+	ur, err := api.AddRoute("/widgets/{id}", "get", "widgets", "", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if err := api.AddParam(ur, spec.RouteParam{
+		Name:     "id",
+		Location: "path",
+		Required: true,
+		Schema:   &spec.Schema{Type: spec.String.String(), Format: "uuid"},
+	}); err != nil {
+		t.Fatalf("AddParam: %v", err)
+	}
+
+	return api
+}
+
+func TestValidateRequestEnforcesParamFormat(t *testing.T) {
+	v := New(newUUIDParamAPI(t))
+
+	r := httptest.NewRequest(http.MethodGet, "/widgets/not-a-uuid", nil)
+	if _, err := v.ValidateRequest(r); err == nil {
+		t.Fatalf("expected a format violation for a non-uuid id, got nil error")
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/widgets/123e4567-e89b-12d3-a456-426614174000", nil)
+	if _, err := v.ValidateRequest(r); err != nil {
+		t.Fatalf("expected a valid uuid to pass, got %v", err)
+	}
+}
+
+func TestValidateRequestEnforcesParamBoolean(t *testing.T) {
+	api := spec.New("t", "1.0", "")
+	ur, err := api.AddRoute("/things", "get", "things", "", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if err := api.AddParam(ur, spec.RouteParam{
+		Name:     "active",
+		Location: "query",
+		Required: true,
+		Schema:   &spec.Schema{Type: spec.Boolean.String()},
+	}); err != nil {
+		t.Fatalf("AddParam: %v", err)
+	}
+
+	v := New(api)
+
+	r := httptest.NewRequest(http.MethodGet, "/things?active=true", nil)
+	if _, err := v.ValidateRequest(r); err != nil {
+		t.Fatalf("expected a valid boolean query param to pass, got %v", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/things?active=not-a-bool", nil)
+	if _, err := v.ValidateRequest(r); err == nil {
+		t.Fatalf("expected a non-boolean query param to be rejected")
+	}
+}
+
+func TestValidateRequestEnforcesRequiredParam(t *testing.T) {
+	api := spec.New("t", "1.0", "")
+	ur, err := api.AddRoute("/search", "get", "search", "", "")
+	if err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if err := api.AddParam(ur, spec.RouteParam{Name: "q", Location: "query", Required: true}); err != nil {
+		t.Fatalf("AddParam: %v", err)
+	}
+
+	v := New(api)
+
+	r := httptest.NewRequest(http.MethodGet, "/search", nil)
+	if _, err := v.ValidateRequest(r); err == nil {
+		t.Fatalf("expected a missing required query param to be rejected")
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/search?q=widgets", nil)
+	if _, err := v.ValidateRequest(r); err != nil {
+		t.Fatalf("expected the request to pass once q is set, got %v", err)
+	}
+}
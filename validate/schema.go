@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+// direction distinguishes request-bound from response-bound validation,
+// since readOnly/writeOnly fields are only enforced in one direction each.
+type direction int
+
+const (
+	directionRequest direction = iota
+	directionResponse
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validateValue validates value (JSON-decoded for a body, or a raw string
+// for a path/query/header param) against s, returning one error per
+// violation found.
+func (v *Validator) validateValue(s *spec.Schema, value any, dir direction, path string) Errors {
+	s = v.resolve(s)
+	if s == nil {
+		return nil
+	}
+
+	var errs Errors
+
+	if dir == directionRequest && s.ReadOnly {
+		return Errors{fmt.Errorf("%s: readOnly field must not be set on a request", path)}
+	}
+	if dir == directionResponse && s.WriteOnly {
+		return Errors{fmt.Errorf("%s: writeOnly field must not be set on a response", path)}
+	}
+
+	if len(s.Enum) > 0 && !enumContains(s.Enum, value) {
+		errs = append(errs, fmt.Errorf("%s: value %v is not one of %v", path, value, s.Enum))
+	}
+
+	switch s.Type {
+	case spec.String.String():
+		str, ok := value.(string)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: expected a string, got %T", path, value))
+			break
+		}
+		errs = append(errs, validateString(s, str, path)...)
+
+	case spec.Integer.String(), spec.Number.String():
+		num, ok := asFloat(value)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: expected a number, got %T", path, value))
+			break
+		}
+		errs = append(errs, validateNumber(s, num, path)...)
+
+	case spec.Boolean.String():
+		if _, ok := asBool(value); !ok {
+			errs = append(errs, fmt.Errorf("%s: expected a boolean, got %T", path, value))
+		}
+
+	case spec.Array.String():
+		items, ok := value.([]any)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: expected an array, got %T", path, value))
+			break
+		}
+		for i, item := range items {
+			errs = append(errs, v.validateValue(s.Items, item, dir, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+
+	case spec.Object.String():
+		obj, ok := value.(map[string]any)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: expected an object, got %T", path, value))
+			break
+		}
+		errs = append(errs, v.validateObject(s, obj, dir, path)...)
+	}
+
+	return errs
+}
+
+// validateObject checks s.Required against obj and recurses into every
+// declared property present in obj; properties not declared on s are
+// ignored (additionalProperties is not currently enforced).
+func (v *Validator) validateObject(s *spec.Schema, obj map[string]any, dir direction, path string) Errors {
+	var errs Errors
+
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			errs = append(errs, fmt.Errorf("%s.%s: required field is missing", path, name))
+		}
+	}
+
+	for name, val := range obj {
+		fieldSchema, declared := s.Properties[name]
+		if !declared {
+			continue
+		}
+		errs = append(errs, v.validateValue(fieldSchema, val, dir, path+"."+name)...)
+	}
+
+	return errs
+}
+
+func validateString(s *spec.Schema, str, path string) Errors {
+	var errs Errors
+
+	if s.MinLength != nil && len(str) < *s.MinLength {
+		errs = append(errs, fmt.Errorf("%s: length %d is less than minLength %d", path, len(str), *s.MinLength))
+	}
+	if s.MaxLength != nil && len(str) > *s.MaxLength {
+		errs = append(errs, fmt.Errorf("%s: length %d is greater than maxLength %d", path, len(str), *s.MaxLength))
+	}
+	if s.Pattern != "" {
+		if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(str) {
+			errs = append(errs, fmt.Errorf("%s: value %q does not match pattern %q", path, str, s.Pattern))
+		}
+	}
+
+	switch s.Format {
+	case "uuid":
+		if !uuidPattern.MatchString(str) {
+			errs = append(errs, fmt.Errorf("%s: value %q is not a valid uuid", path, str))
+		}
+	case "ipv4":
+		if ip := net.ParseIP(str); ip == nil || ip.To4() == nil {
+			errs = append(errs, fmt.Errorf("%s: value %q is not a valid ipv4 address", path, str))
+		}
+	case "ipv6":
+		if ip := net.ParseIP(str); ip == nil || ip.To4() != nil {
+			errs = append(errs, fmt.Errorf("%s: value %q is not a valid ipv6 address", path, str))
+		}
+	case "date-time", "dateTime":
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			errs = append(errs, fmt.Errorf("%s: value %q is not a valid RFC 3339 date-time", path, str))
+		}
+	}
+
+	return errs
+}
+
+func validateNumber(s *spec.Schema, num float64, path string) Errors {
+	var errs Errors
+
+	if s.Minimum != nil && num < *s.Minimum {
+		errs = append(errs, fmt.Errorf("%s: value %v is less than minimum %v", path, num, *s.Minimum))
+	}
+	if s.Maximum != nil && num > *s.Maximum {
+		errs = append(errs, fmt.Errorf("%s: value %v is greater than maximum %v", path, num, *s.Maximum))
+	}
+	if s.ExclusiveMinimum != nil && num <= *s.ExclusiveMinimum {
+		errs = append(errs, fmt.Errorf("%s: value %v is not greater than exclusiveMinimum %v", path, num, *s.ExclusiveMinimum))
+	}
+	if s.ExclusiveMaximum != nil && num >= *s.ExclusiveMaximum {
+		errs = append(errs, fmt.Errorf("%s: value %v is not less than exclusiveMaximum %v", path, num, *s.ExclusiveMaximum))
+	}
+
+	return errs
+}
+
+func enumContains(enum []any, value any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// asFloat accepts either a JSON-decoded float64 or a raw path/query/header
+// param string.
+func asFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// asBool accepts either a JSON-decoded bool or a raw path/query/header
+// param string.
+func asBool(v any) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case string:
+		parsed, err := strconv.ParseBool(b)
+		return parsed, err == nil
+	default:
+		return false, false
+	}
+}
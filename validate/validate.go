@@ -0,0 +1,210 @@
+// Package validate provides http.Handler middleware that validates
+// incoming requests and outgoing responses against a built *spec.OpenAPI,
+// turning it from documentation into an enforcement layer.
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+// Errors aggregates every validation failure found for a single request or
+// response so a caller sees all of them in one shot instead of failing
+// fast on the first.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validator validates HTTP requests and responses against the routes
+// recorded on an *spec.OpenAPI.
+type Validator struct {
+	api *spec.OpenAPI
+}
+
+// New returns a Validator for api.
+func New(api *spec.OpenAPI) *Validator {
+	return &Validator{api: api}
+}
+
+// pathCandidate is a pattern from api.Paths that matched a request path,
+// together with how specific it is - used by Match to make route
+// selection deterministic when a literal path (e.g. "/users/active") and
+// a parameterized sibling (e.g. "/users/{id}") both match the same
+// segments.
+type pathCandidate struct {
+	pattern     string
+	methods     spec.OperationMap
+	bound       map[string]string
+	specificity int
+}
+
+// Match finds the path and operation in api.Paths matching method and
+// path, binding any {name} path segments along the way. ok is false if no
+// route matches. When more than one pattern matches the same request path
+// (a literal segment and a {param} sibling both fit), the pattern with
+// more literal segments wins, with ties broken lexicographically so the
+// choice never depends on Go's randomized map iteration order.
+func (v *Validator) Match(method, path string) (ur spec.UniqueRoute, op spec.Operation, pathParams map[string]string, ok bool) {
+	reqSegs := strings.Split(strings.Trim(path, "/"), "/")
+
+	var candidates []pathCandidate
+	for pattern, methods := range v.api.Paths {
+		segs := strings.Split(strings.Trim(pattern, "/"), "/")
+		bound, matched := matchSegments(segs, reqSegs)
+		if !matched {
+			continue
+		}
+		candidates = append(candidates, pathCandidate{
+			pattern:     pattern,
+			methods:     methods,
+			bound:       bound,
+			specificity: literalSegmentCount(segs),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].specificity != candidates[j].specificity {
+			return candidates[i].specificity > candidates[j].specificity
+		}
+		return candidates[i].pattern < candidates[j].pattern
+	})
+
+	m := spec.Method(strings.ToLower(method))
+	for _, c := range candidates {
+		operation, found := c.methods[m]
+		if !found {
+			continue
+		}
+		return spec.UniqueRoute{Path: c.pattern, Method: m}, operation, c.bound, true
+	}
+
+	return ur, op, nil, false
+}
+
+// literalSegmentCount counts the segments of a path pattern that are not
+// a {name} placeholder - used by Match to prefer the most specific of
+// several matching patterns.
+func literalSegmentCount(segs []string) int {
+	n := 0
+	for _, seg := range segs {
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			n++
+		}
+	}
+	return n
+}
+
+// matchSegments compares a path pattern's segments (which may contain
+// {name} placeholders) against a request path's segments, returning the
+// bound placeholder values on a match.
+func matchSegments(pattern, actual []string) (map[string]string, bool) {
+	if len(pattern) != len(actual) {
+		return nil, false
+	}
+
+	bound := map[string]string{}
+	for i, seg := range pattern {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			bound[strings.Trim(seg, "{}")] = actual[i]
+			continue
+		}
+		if seg != actual[i] {
+			return nil, false
+		}
+	}
+
+	return bound, true
+}
+
+// resolve follows s.Ref into api.Components.Schemas until it reaches a
+// schema with no $ref, guarding against reference cycles.
+func (v *Validator) resolve(s *spec.Schema) *spec.Schema {
+	seen := map[string]bool{}
+	for s != nil && s.Ref != "" {
+		name := refName(s.Ref)
+		if seen[name] {
+			break
+		}
+		seen[name] = true
+
+		next, ok := v.api.Components.Schemas[name]
+		if !ok {
+			break
+		}
+		s = next
+	}
+
+	return s
+}
+
+func refName(ref string) string {
+	i := strings.LastIndex(ref, "/")
+	if i < 0 {
+		return ref
+	}
+	return ref[i+1:]
+}
+
+// firstQuery returns the first value bound to name in values, and whether
+// it was present at all.
+func firstQuery(values url.Values, name string) (string, bool) {
+	vs, ok := values[name]
+	if !ok || len(vs) == 0 {
+		return "", false
+	}
+	return vs[0], true
+}
+
+// readAndRestore fully reads *body and replaces it with a fresh reader
+// over the same bytes, so a body can be validated without consuming it for
+// the handler that runs afterwards.
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// validateBody unmarshals body as JSON and validates it against the
+// schema declared for contentType in content, resolving $ref first. When
+// contentType is empty and content declares exactly one media type, that
+// one is used.
+func (v *Validator) validateBody(content map[string]spec.Media, contentType string, body []byte, dir direction) Errors {
+	mimeType, _, _ := mime.ParseMediaType(contentType)
+	if mimeType == "" {
+		mimeType = contentType
+	}
+
+	media, ok := content[mimeType]
+	if !ok && mimeType == "" && len(content) == 1 {
+		for _, m := range content {
+			media, ok = m, true
+		}
+	}
+	if !ok {
+		return Errors{fmt.Errorf("content-type %q is not declared for this operation", contentType)}
+	}
+
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		return Errors{fmt.Errorf("body is not valid JSON: %w", err)}
+	}
+
+	return v.validateValue(&media.Schema, value, dir, "body")
+}
@@ -0,0 +1,50 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/zJeremiah/go-openapi/spec"
+)
+
+func newSiblingRouteAPI(t *testing.T) *spec.OpenAPI {
+	t.Helper()
+
+	api := spec.New("t", "1.0", "")
+	if _, err := api.AddRoute("/users/{id}", "get", "users", "", ""); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+	if _, err := api.AddRoute("/users/active", "get", "users", "", ""); err != nil {
+		t.Fatalf("AddRoute: %v", err)
+	}
+
+	return api
+}
+
+func TestMatchPrefersLiteralOverParamSibling(t *testing.T) {
+	v := New(newSiblingRouteAPI(t))
+
+	for i := 0; i < 50; i++ {
+		ur, _, _, ok := v.Match("GET", "/users/active")
+		if !ok {
+			t.Fatalf("expected /users/active to match a route")
+		}
+		if ur.Path != "/users/active" {
+			t.Fatalf("expected the literal /users/active route to win over /users/{id}, got %q", ur.Path)
+		}
+	}
+}
+
+func TestMatchStillBindsParamRoute(t *testing.T) {
+	v := New(newSiblingRouteAPI(t))
+
+	ur, _, params, ok := v.Match("GET", "/users/42")
+	if !ok {
+		t.Fatalf("expected /users/42 to match a route")
+	}
+	if ur.Path != "/users/{id}" {
+		t.Fatalf("expected /users/{id} to match, got %q", ur.Path)
+	}
+	if params["id"] != "42" {
+		t.Fatalf("expected id=42 to be bound, got %+v", params)
+	}
+}